@@ -0,0 +1,403 @@
+package cp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+	
+	"github.com/creamsensation/form"
+	
+	"github.com/creamsensation/util/constant/contentType"
+	"github.com/creamsensation/util/constant/header"
+)
+
+type ValidationError struct {
+	Fields map[string][]string
+}
+
+type bindField struct {
+	field reflect.StructField
+	value reflect.Value
+}
+
+const (
+	bindPath     = "path"
+	bindQuery    = "query"
+	bindForm     = "form"
+	bindJson     = "json"
+	bindValidate = "validate"
+)
+
+const (
+	bindRuleRequired = "required"
+	bindRuleEmail    = "email"
+	bindRuleMin      = "min"
+	bindRuleMax      = "max"
+)
+
+const (
+	bindMimeJson         = "application/json"
+	defaultBindFormLimit = 32
+)
+
+var (
+	bindEmailMatcher = regexp.MustCompile(`^[-A-Za-z0-9!#$%&'*+/=?^_{|}~]+(?:\.[-A-Za-z0-9!#$%&'*+/=?^_{|}~]+)*@(?:[A-Za-z0-9](?:[-A-Za-z0-9]*[A-Za-z0-9])?\.)+[A-Za-z0-9](?:[-A-Za-z0-9]*[A-Za-z0-9])?$`)
+	bindTimeLayouts  = []string{time.RFC3339, "2006-01-02T15:04", time.DateTime, time.DateOnly}
+	timeType         = reflect.TypeOf(time.Time{})
+	
+	defaultBindMessages = form.Messages{
+		Email:     "email value is invalid",
+		Required:  "field is required",
+		MinText:   "field length is smaller than should be",
+		MaxText:   "field length is higher than should be",
+		MinNumber: "field value is smaller than should be",
+		MaxNumber: "field value is higher than should be",
+		Multipart: "invalid file",
+		Invalid:   "invalid value",
+	}
+)
+
+func FormValidators[T any](name string) []form.Validator {
+	result := make([]form.Validator, 0)
+	t := reflect.TypeFor[T]()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return result
+	}
+	for _, f := range collectBindFields(reflect.New(t).Elem()) {
+		if createBindFieldName(f.field) != name {
+			continue
+		}
+		for _, rule := range parseBindRules(f.field) {
+			key, value, _ := strings.Cut(rule, "=")
+			switch key {
+			case bindRuleRequired:
+				result = append(result, form.Validate.Required())
+			case bindRuleEmail:
+				result = append(result, form.Validate.Email())
+			case bindRuleMin, bindRuleMax:
+				limit, err := strconv.Atoi(value)
+				if err != nil {
+					continue
+				}
+				if key == bindRuleMin {
+					result = append(result, form.Validate.Min(limit))
+					continue
+				}
+				result = append(result, form.Validate.Max(limit))
+			}
+		}
+	}
+	return result
+}
+
+func (e *ValidationError) Error() string {
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return fmt.Sprintf("%v: %s", ErrorValidation, strings.Join(names, ", "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrorValidation
+}
+
+func (e *ValidationError) Messages(name string) []string {
+	return e.Fields[name]
+}
+
+func (e *ValidationError) add(name, message string) {
+	e.Fields[name] = append(e.Fields[name], message)
+}
+
+func (c *ctx) Bind(dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrorInvalidBindTarget
+	}
+	if strings.HasPrefix(c.r.Header.Get(header.ContentType), bindMimeJson) && c.r.Body != nil {
+		if err := json.NewDecoder(c.r.Body).Decode(dst); err != nil && !errors.Is(err, io.EOF) {
+			return &ParamError{Name: bindJson, Err: ErrorInvalidParam}
+		}
+	}
+	if err := c.parseBindForm(); err != nil {
+		return &ParamError{Name: bindForm, Err: ErrorInvalidParam}
+	}
+	messages := c.createBindMessages()
+	result := &ValidationError{Fields: make(map[string][]string)}
+	for _, f := range collectBindFields(v.Elem()) {
+		name := createBindFieldName(f.field)
+		values := c.findBindValues(f)
+		if len(values) > 0 {
+			if err := setBindValue(f.value, values); err != nil {
+				result.add(name, messages.Invalid)
+				continue
+			}
+		}
+		for _, message := range validateBindField(f, len(values) > 0, messages) {
+			result.add(name, message)
+		}
+	}
+	if len(result.Fields) > 0 {
+		return result
+	}
+	return nil
+}
+
+func (c *ctx) parseBindForm() error {
+	ct := c.r.Header.Get(header.ContentType)
+	if strings.Contains(ct, contentType.MultipartForm) {
+		limit := c.config.Parser.Limit
+		if limit == 0 {
+			limit = defaultBindFormLimit
+		}
+		err := c.r.ParseMultipartForm(int64(limit) << 20)
+		if errors.Is(err, http.ErrNotMultipart) {
+			return nil
+		}
+		return err
+	}
+	if strings.Contains(ct, contentType.Form) {
+		return c.r.ParseForm()
+	}
+	return nil
+}
+
+func (c *ctx) createBindMessages() form.Messages {
+	messages := defaultBindMessages
+	custom := c.config.Localization.Form
+	for _, item := range []struct {
+		target *string
+		value  string
+	}{
+		{&messages.Email, custom.Email},
+		{&messages.Required, custom.Required},
+		{&messages.MinText, custom.MinText},
+		{&messages.MaxText, custom.MaxText},
+		{&messages.MinNumber, custom.MinNumber},
+		{&messages.MaxNumber, custom.MaxNumber},
+		{&messages.Multipart, custom.Multipart},
+		{&messages.Invalid, custom.Invalid},
+	} {
+		if len(item.value) > 0 {
+			*item.target = item.value
+		}
+	}
+	return messages
+}
+
+func (c *ctx) findBindValues(f bindField) []string {
+	if name := parseBindTagName(f.field, bindPath); len(name) > 0 {
+		if value := c.r.PathValue(name); len(value) > 0 {
+			return []string{value}
+		}
+	}
+	if name := parseBindTagName(f.field, bindQuery); len(name) > 0 {
+		values := c.r.URL.Query()[name]
+		if f.value.Kind() == reflect.Slice {
+			values = splitParamValues(values)
+		}
+		if len(values) > 0 {
+			return values
+		}
+	}
+	if name := parseBindTagName(f.field, bindForm); len(name) > 0 {
+		if values := c.r.PostForm[name]; len(values) > 0 {
+			return values
+		}
+	}
+	return nil
+}
+
+func collectBindFields(v reflect.Value) []bindField {
+	result := make([]bindField, 0)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			result = append(result, collectBindFields(v.Field(i))...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		result = append(result, bindField{field: f, value: v.Field(i)})
+	}
+	return result
+}
+
+func createBindFieldName(f reflect.StructField) string {
+	for _, tag := range []string{bindForm, bindJson, bindQuery, bindPath} {
+		if name := parseBindTagName(f, tag); len(name) > 0 {
+			return name
+		}
+	}
+	return f.Name
+}
+
+func parseBindTagName(f reflect.StructField, tag string) string {
+	name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+func parseBindRules(f reflect.StructField) []string {
+	result := make([]string, 0)
+	for _, rule := range strings.Split(f.Tag.Get(bindValidate), ",") {
+		rule = strings.TrimSpace(rule)
+		if len(rule) == 0 {
+			continue
+		}
+		result = append(result, rule)
+	}
+	return result
+}
+
+func setBindValue(v reflect.Value, values []string) error {
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		s := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setBindScalar(s.Index(i), value); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	}
+	return setBindScalar(v, values[0])
+}
+
+func setBindScalar(v reflect.Value, value string) error {
+	if v.Type() == timeType {
+		for _, layout := range bindTimeLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				v.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return ErrorInvalidParam
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		p := reflect.New(v.Type().Elem())
+		if err := setBindScalar(p.Elem(), value); err != nil {
+			return err
+		}
+		v.Set(p)
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return ErrorInvalidParam
+	}
+	return nil
+}
+
+func validateBindField(f bindField, provided bool, messages form.Messages) []string {
+	result := make([]string, 0)
+	v := f.value
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	for _, rule := range parseBindRules(f.field) {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case bindRuleRequired:
+			if v.IsZero() || isEmptyBindValue(v) {
+				return append(result, messages.Required)
+			}
+		case bindRuleEmail:
+			if v.Kind() == reflect.String && v.Len() > 0 && !bindEmailMatcher.MatchString(v.String()) {
+				result = append(result, messages.Email)
+			}
+		case bindRuleMin, bindRuleMax:
+			limit, err := strconv.ParseFloat(value, 64)
+			if err != nil || isEmptyBindValue(v) || (!provided && v.IsZero()) {
+				continue
+			}
+			if message, ok := validateBindLimit(v, key == bindRuleMin, limit, messages); !ok {
+				result = append(result, message)
+			}
+		}
+	}
+	return result
+}
+
+func validateBindLimit(v reflect.Value, min bool, limit float64, messages form.Messages) (string, bool) {
+	var size float64
+	text := false
+	switch v.Kind() {
+	case reflect.String:
+		size = float64(utf8.RuneCountInString(v.String()))
+		text = true
+	case reflect.Slice, reflect.Map:
+		size = float64(v.Len())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		size = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		size = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		size = v.Float()
+	default:
+		return "", true
+	}
+	switch {
+	case min && size < limit && text:
+		return messages.MinText, false
+	case min && size < limit:
+		return messages.MinNumber, false
+	case !min && size > limit && text:
+		return messages.MaxText, false
+	case !min && size > limit:
+		return messages.MaxNumber, false
+	}
+	return "", true
+}
+
+func isEmptyBindValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer:
+		return v.IsNil()
+	case reflect.String, reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package cp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	
+	"github.com/stretchr/testify/assert"
+	
+	"github.com/creamsensation/config"
+	"github.com/creamsensation/form"
+)
+
+type testBindPagination struct {
+	Page int `query:"page" validate:"min=1"`
+}
+
+type testBindForm struct {
+	testBindPagination
+	Id     int      `path:"id"`
+	Email  string   `form:"email" json:"email" validate:"required,email"`
+	Name   string   `form:"name" json:"name" validate:"required,min=3,max=10"`
+	Tags   []string `query:"tags"`
+	Active *bool    `form:"active" json:"active"`
+}
+
+func TestBind(t *testing.T) {
+	messages := form.Messages{Required: "povinné pole", Email: "neplatný email"}
+	app := TestApp(
+		t.TempDir(), config.Config{
+			Localization: config.Localization{Form: messages},
+		},
+	)
+	var bound testBindForm
+	var bindErr error
+	app.Post(
+		"/users/{id}/", func(c Ctx) error {
+			bound = testBindForm{}
+			bindErr = c.Bind(&bound)
+			var validationErr *ValidationError
+			if errors.As(bindErr, &validationErr) {
+				return c.Response().Text(strings.Join(validationErr.Messages("email"), ";"))
+			}
+			if bindErr != nil {
+				return bindErr
+			}
+			return c.Response().Text("ok")
+		},
+	)
+	t.Run(
+		"form", func(t *testing.T) {
+			body := url.Values{"email": {"admin@example.com"}, "name": {"Admin"}, "active": {"on"}}
+			r := httptest.NewRequest(http.MethodPost, "/users/5/?page=2&tags=a,b", strings.NewReader(body.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, r)
+			assert.Equal(t, "ok", w.Body.String())
+			assert.Equal(t, 5, bound.Id)
+			assert.Equal(t, 2, bound.Page)
+			assert.Equal(t, "admin@example.com", bound.Email)
+			assert.Equal(t, []string{"a", "b"}, bound.Tags)
+			assert.True(t, *bound.Active)
+		},
+	)
+	t.Run(
+		"json", func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/users/5/", strings.NewReader(`{"email":"admin@example.com","name":"Admin"}`))
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, r)
+			assert.Equal(t, "ok", w.Body.String())
+			assert.Equal(t, "Admin", bound.Name)
+			assert.Nil(t, bound.Active)
+		},
+	)
+	t.Run(
+		"empty json", func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/users/5/?page=3", nil)
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, r)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "povinné pole", w.Body.String())
+			assert.ErrorIs(t, bindErr, ErrorValidation)
+			assert.Equal(t, 5, bound.Id)
+			assert.Equal(t, 3, bound.Page)
+		},
+	)
+	t.Run(
+		"validation", func(t *testing.T) {
+			body := url.Values{"email": {"invalid"}, "name": {"Ad"}}
+			r := httptest.NewRequest(http.MethodPost, "/users/5/?page=0", strings.NewReader(body.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, r)
+			assert.Equal(t, "neplatný email", w.Body.String())
+			var validationErr *ValidationError
+			assert.ErrorAs(t, bindErr, &validationErr)
+			assert.ErrorIs(t, bindErr, ErrorValidation)
+			assert.Equal(t, []string{"field length is smaller than should be"}, validationErr.Messages("name"))
+			assert.Equal(t, []string{"field value is smaller than should be"}, validationErr.Messages("page"))
+			
+			r = httptest.NewRequest(http.MethodPost, "/users/5/", strings.NewReader(url.Values{"name": {"Admin"}}.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w = httptest.NewRecorder()
+			app.ServeHTTP(w, r)
+			assert.Equal(t, "povinné pole", w.Body.String())
+		},
+	)
+	t.Run(
+		"invalid", func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/users/5/", strings.NewReader(`{`))
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, r)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		},
+	)
+	t.Run(
+		"form validators", func(t *testing.T) {
+			assert.Len(t, FormValidators[testBindForm]("name"), 3)
+			assert.Len(t, FormValidators[*testBindForm]("email"), 2)
+			assert.Empty(t, FormValidators[testBindForm]("tags"))
+		},
+	)
+}
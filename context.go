@@ -4,7 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strings"
-	"sync"
+	"time"
 
 	"github.com/creamsensation/filesystem"
 
@@ -21,8 +21,10 @@ import (
 
 type Ctx interface {
 	Auth(dbname ...string) auth.Manager
+	Bind(dst any) error
 	Cache() cache.Client
 	Config() config.Config
+	Context() context.Context
 	Continue() error
 	Cookie() cookie.Cookie
 	Create() Factory
@@ -33,23 +35,30 @@ type Ctx interface {
 	Files() filesystem.Client
 	Flash() Flash
 	Generate() Generator
+	Get(key string) any
 	Lang() Lang
 	Page() Page
 	Parse() parser.Parse
 	Request() Request
 	Response() Response
+	Service(key any) any
+	Set(key string, value any) Ctx
 	Translate(key string, args ...map[string]any) string
+	WithValue(key, value any) Ctx
 }
 
 type ctx struct {
-	context.Context
+	context          context.Context
 	err              error
+	auths            map[string]auth.Manager
+	cache            cache.Client
+	chain            []Handler
+	next             int
 	cachedComponents *map[string]MandatoryComponent
 	config           config.Config
 	cookie           cookie.Cookie
 	csrf             csrf.Csrf
 	files            filesystem.Client
-	mu               *sync.Mutex
 	page             *page
 	r                *http.Request
 	w                http.ResponseWriter
@@ -60,6 +69,10 @@ type ctx struct {
 	assets           *assets
 	lang             *lang
 	component        *componentCtx
+	proxies          proxyConfig
+	services         map[any]*service
+	instances        map[any]any
+	values           map[string]any
 	write            *bool
 }
 
@@ -70,27 +83,34 @@ type ctxParam struct {
 	layout           *layout
 	matchedRoute     *Route
 	routes           *[]*Route
+	proxies          proxyConfig
+	services         map[any]*service
 	r                *http.Request
 	w                http.ResponseWriter
 }
 
 func createContext(p ctxParam) *ctx {
-	cx := context.Background()
+	cx := p.r.Context()
 	write := true
 	c := &ctx{
-		Context:          cx,
+		context:          cx,
 		cachedComponents: p.cachedComponents,
 		config:           p.config,
 		files:            filesystem.New(cx, p.config.Filesystem),
-		mu:               &sync.Mutex{},
 		page:             createPage(),
 		route:            p.matchedRoute,
 		routes:           p.routes,
 		r:                p.r,
 		w:                p.w,
 		assets:           p.assets,
+		proxies:          p.proxies,
+		services:         p.services,
+		instances:        make(map[any]any),
+		auths:            make(map[string]auth.Manager),
+		values:           make(map[string]any),
 		write:            &write,
 	}
+	c.cache = cache.New(cx, p.config.Cache.Memory, p.config.Cache.Redis)
 	c.cookie = cookie.New(c.r, c.w, c.createCookiePathBasedOnRouterPrefix())
 	c.csrf = csrf.New(
 		csrf.Cache(c.Cache()),
@@ -98,11 +118,15 @@ func createContext(p ctxParam) *ctx {
 		csrf.Request(p.r),
 	)
 	c.lang = createLang(c.Config(), c.Request(), c.Cookie())
+	layoutName := Main
+	if p.matchedRoute != nil && len(p.matchedRoute.Layout) > 0 {
+		layoutName = p.matchedRoute.Layout
+	}
 	c.response = &response{
 		Sender: sender.New(&write),
 		ctx:    c,
 		layout: p.layout,
-		l:      p.layout.factories[Main],
+		l:      p.layout.factories[layoutName],
 	}
 	c.state = createState(c.Cache(), c.Cookie())
 	return c
@@ -115,37 +139,67 @@ func (c *ctx) Auth(dbname ...string) auth.Manager {
 	if len(dbname) > 0 {
 		dbn = dbname[0]
 	}
+	if a, ok := c.auths[dbn]; ok {
+		return a
+	}
 	if len(c.config.Database) > 0 {
 		db, ok = c.config.Database[dbn]
 		if !ok {
 			panic(ErrorInvalidDatabase)
 		}
 	}
-	return auth.New(
+	a := auth.New(
 		db,
 		c.r,
 		c.w,
 		c.cookie,
-		c.Cache(),
+		c.cache,
 		c.config.Security.Auth,
 	)
+	c.auths[dbn] = a
+	return a
 }
 
 func (c *ctx) Cache() cache.Client {
-	return cache.New(c.Context, c.config.Cache.Memory, c.config.Cache.Redis)
+	return c.cache
 }
 
 func (c *ctx) Config() config.Config {
 	return c.config
 }
 
+func (c *ctx) Context() context.Context {
+	return c.context
+}
+
+func (c *ctx) Deadline() (time.Time, bool) {
+	return c.context.Deadline()
+}
+
+func (c *ctx) Done() <-chan struct{} {
+	return c.context.Done()
+}
+
+func (c *ctx) Err() error {
+	return c.context.Err()
+}
+
+func (c *ctx) Value(key any) any {
+	return c.context.Value(key)
+}
+
 func (c *ctx) Cookie() cookie.Cookie {
 	return c.cookie
 }
 
 func (c *ctx) Continue() error {
-	c.mu.Unlock()
-	return nil
+	if c.next >= len(c.chain) {
+		return nil
+	}
+	fn := c.chain[c.next]
+	c.next++
+	c.err = fn(c)
+	return c.err
 }
 
 func (c *ctx) Create() Factory {
@@ -188,6 +242,10 @@ func (c *ctx) Generate() Generator {
 	return &generator{c}
 }
 
+func (c *ctx) Get(key string) any {
+	return c.values[key]
+}
+
 func (c *ctx) Lang() Lang {
 	return c.lang
 }
@@ -201,13 +259,18 @@ func (c *ctx) Parse() parser.Parse {
 }
 
 func (c *ctx) Request() Request {
-	return request{c.r, c.route}
+	return request{c.r, c.route, c.proxies}
 }
 
 func (c *ctx) Response() Response {
 	return c.response
 }
 
+func (c *ctx) Set(key string, value any) Ctx {
+	c.values[key] = value
+	return c
+}
+
 func (c *ctx) Translate(key string, args ...map[string]any) string {
 	if !c.config.Localization.Enabled {
 		return key
@@ -215,6 +278,12 @@ func (c *ctx) Translate(key string, args ...map[string]any) string {
 	return c.config.Localization.Translator.Translate(c.Lang().Current(), key, args...)
 }
 
+func (c *ctx) WithValue(key, value any) Ctx {
+	c.context = context.WithValue(c.context, key, value)
+	c.r = c.r.WithContext(c.context)
+	return c
+}
+
 func (c *ctx) createCookiePathBasedOnRouterPrefix() string {
 	switch p := c.config.Router.Prefix.Path.(type) {
 	case string:
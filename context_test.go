@@ -0,0 +1,79 @@
+package cp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext(t *testing.T) {
+	type contextKey struct{}
+	app := TestApp(t.TempDir())
+	app.Use(
+		func(c Ctx) error {
+			c.WithValue(contextKey{}, "user")
+			return c.Continue()
+		},
+	)
+	app.Get(
+		"/", func(c Ctx) error {
+			assert.Equal(t, c.Context(), c.Request().Raw().Context())
+			if err := c.Context().Err(); err != nil {
+				return c.Response().Status(http.StatusRequestTimeout).Text(err.Error())
+			}
+			return c.Response().Text(c.Context().Value(contextKey{}).(string))
+		},
+	)
+	t.Run(
+		"value", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "user", w.Body.String())
+		},
+	)
+	t.Run(
+		"canceled", func(t *testing.T) {
+			cx, cancel := context.WithCancel(context.Background())
+			cancel()
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(cx))
+			assert.Equal(t, http.StatusRequestTimeout, w.Code)
+			assert.Equal(t, context.Canceled.Error(), w.Body.String())
+		},
+	)
+}
+
+func TestContextClients(t *testing.T) {
+	app := TestApp(t.TempDir())
+	app.Get(
+		"/", func(c Ctx) error {
+			assert.Same(t, c.Cache(), c.Cache())
+			assert.Same(t, c.Auth(), c.Auth(Main))
+			return c.Response().Text("ok")
+		},
+	)
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func BenchmarkContext(b *testing.B) {
+	app := TestApp(b.TempDir())
+	app.Get(
+		"/", func(c Ctx) error {
+			c.Cache()
+			c.Auth()
+			return c.Response().Text("ok")
+		},
+	)
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		app.ServeHTTP(httptest.NewRecorder(), r)
+	}
+}
@@ -1,29 +1,65 @@
 package cp
 
 import (
-	"fmt"
+	"context"
+	"io"
 	"log"
 	"net/http"
+	"os"
+	"strings"
+	"sync"
 	
+	"github.com/creamsensation/cache/memory"
 	"github.com/creamsensation/config"
 )
 
 type Creampuff interface {
 	Router
+	http.Handler
 	ErrorHandler(handler Handler) Creampuff
 	Layout() Layout
+	Module(modules ...Module) Creampuff
 	Run(address string)
+	RunContext(ctx context.Context, address string) error
+	RunTLS(address, certFile, keyFile string)
+	RunTLSContext(ctx context.Context, address, certFile, keyFile string) error
+	Shutdown(ctx context.Context) error
+	OnStart(hooks ...Hook) Creampuff
+	OnShutdown(hooks ...Hook) Creampuff
+	OnRequest(hooks ...ObserverHook) Creampuff
+	OnResponse(hooks ...ObserverHook) Creampuff
+	Provide(key any, provider Provider) Creampuff
+	ProvideRequest(key any, provider RequestProvider) Creampuff
+	Server(config ServerConfig) Creampuff
 	Mux() *http.ServeMux
+	Routes() []Route
+	Validate() error
+	PrintRoutes(w io.Writer) error
+	RoutesHandler() Handler
 }
 
 type core struct {
 	*router
 	*assets
-	config       config.Config
-	errorHandler Handler
-	layout       *layout
-	mux          *http.ServeMux
-	routes       []*Route
+	config         config.Config
+	errorHandler   Handler
+	layout         *layout
+	mux            *http.ServeMux
+	routes         []*Route
+	hosts          []*host
+	issues         []error
+	patterns       map[patternKey]*patternHandler
+	serverMu       sync.Mutex
+	servers        []*http.Server
+	stopped        bool
+	done           chan struct{}
+	serverConfig   ServerConfig
+	startHooks     []Hook
+	shutdownHooks  []Hook
+	requestHooks   []ObserverHook
+	responseHooks  []ObserverHook
+	services       map[any]*service
+	trustedProxies proxyConfig
 }
 
 const (
@@ -34,15 +70,32 @@ const (
 \________/\____/___/\________/\___/____/\__/__/__/\______/  \________/\_______/ \_______/`
 )
 
+var (
+	defaultMemoryCacheDir = os.TempDir() + "/.creamsensation/cache/"
+)
+
 func New(cfg config.Config) Creampuff {
+	if cfg.Cache.Memory == nil && cfg.Cache.Redis == nil {
+		cfg.Cache.Memory = memory.New(defaultMemoryCacheDir)
+	}
 	mux := http.NewServeMux()
 	rts := make([]*Route, 0)
 	c := &core{
-		config:       cfg,
-		errorHandler: defaultErrorHandler,
-		layout:       createLayout(),
-		mux:          mux,
-		routes:       rts,
+		config:        cfg,
+		errorHandler:  defaultErrorHandler,
+		layout:        createLayout(),
+		mux:           mux,
+		routes:        rts,
+		hosts:         make([]*host, 0),
+		issues:        make([]error, 0),
+		patterns:      make(map[patternKey]*patternHandler),
+		serverConfig:  defaultServerConfig,
+		done:          make(chan struct{}),
+		startHooks:    make([]Hook, 0),
+		shutdownHooks: make([]Hook, 0),
+		requestHooks:  make([]ObserverHook, 0),
+		responseHooks: make([]ObserverHook, 0),
+		services:      make(map[any]*service),
 	}
 	c.router = &router{
 		config: cfg,
@@ -55,7 +108,7 @@ func New(cfg config.Config) Creampuff {
 		public: cfg.App.Public,
 	}
 	c.router.core = c
-	c.router.createGetWildcardRoute()
+	c.router.createWildcardRoute()
 	c.onInit()
 	return c
 }
@@ -70,14 +123,60 @@ func (c *core) Layout() Layout {
 }
 
 func (c *core) Run(address string) {
-	fmt.Println(logo)
-	log.Fatalln(http.ListenAndServe(address, c.mux))
+	if err := c.RunContext(context.Background(), address); err != nil {
+		log.Fatalln(err)
+	}
 }
 
 func (c *core) Mux() *http.ServeMux {
 	return c.mux
 }
 
+func (c *core) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.setHstsHeader(w, r)
+	r.Host = strings.ToLower(r.Host)
+	for _, h := range c.hosts {
+		if !h.match(r) {
+			continue
+		}
+		if h.isDynamic() {
+			h.mux.ServeHTTP(w, r)
+			return
+		}
+		break
+	}
+	c.mux.ServeHTTP(w, r)
+}
+
+func (c *core) Routes() []Route {
+	result := make([]Route, len(*c.router.routes))
+	for i, r := range *c.router.routes {
+		result[i] = *r
+	}
+	return result
+}
+
+func (c *core) notFoundHandler(ctx Ctx) error {
+	ctx.Response().Status(http.StatusNotFound)
+	return c.errorHandler(ctx)
+}
+
+func (c *core) createFallbackHandler(r *router) Handler {
+	return func(ctx Ctx) error {
+		methods := r.findAllowedMethods(ctx.Request().Raw())
+		if len(methods) == 0 {
+			return c.notFoundHandler(ctx)
+		}
+		ctx.Response().Header().Set(headerAllow, strings.Join(methods, ", "))
+		if ctx.Request().Is().Options() {
+			return ctx.Response().Status(http.StatusNoContent).Text("")
+		}
+		ctx.Response().Status(http.StatusMethodNotAllowed)
+		return c.errorHandler(ctx)
+	}
+}
+
 func (c *core) onInit() {
 	c.assets.mustRead()
+	c.issues = append(c.issues, c.validateConfig()...)
 }
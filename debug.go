@@ -0,0 +1,48 @@
+package cp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+func (c *core) PrintRoutes(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "NAME\tLANG\tMETHODS\tPATH\tFIREWALLS\tMIDDLEWARES"); err != nil {
+		return err
+	}
+	for _, r := range c.Routes() {
+		_, err := fmt.Fprintf(
+			tw, "%s\t%s\t%s\t%s\t%d\t%d\n",
+			formatRouteTableValue(r.Name),
+			formatRouteTableValue(r.Lang),
+			strings.Join(r.Methods, ","),
+			r.Host+r.Path,
+			len(r.Firewalls),
+			r.MiddlewareCount(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+func (c *core) RoutesHandler() Handler {
+	return func(ctx Ctx) error {
+		var buf bytes.Buffer
+		if err := c.PrintRoutes(&buf); err != nil {
+			return err
+		}
+		return ctx.Response().Text(buf.String())
+	}
+}
+
+func formatRouteTableValue(value string) string {
+	if len(value) == 0 {
+		return "-"
+	}
+	return value
+}
@@ -5,10 +5,28 @@ import (
 )
 
 var (
-	ErrorInvalidDatabase = errors.New("invalid database")
-	ErrorInvalidLayout   = errors.New("invalid layout")
+	ErrorInvalidDatabase    = errors.New("invalid database")
+	ErrorInvalidLayout      = errors.New("invalid layout")
+	ErrorInvalidLanguage    = errors.New("invalid language")
+	ErrorDuplicateRouteName = errors.New("duplicate route name")
+	ErrorConflictingRoute   = errors.New("conflicting route")
+	ErrorMissingLanguages   = errors.New("missing localization languages")
+	ErrorInvalidProxy       = errors.New("invalid trusted proxy")
+	ErrorInvalidProxyHeader = errors.New("invalid proxy header")
+	ErrorInvalidService     = errors.New("invalid service")
+	ErrorCircularService    = errors.New("circular service")
+	ErrorInvalidParam       = errors.New("invalid parameter")
+	ErrorMissingParam       = errors.New("missing parameter")
+	ErrorValidation         = errors.New("validation failed")
+	ErrorInvalidBindTarget  = errors.New("invalid bind target")
 )
 
+func isBadRequestError(err error) bool {
+	var paramErr *ParamError
+	var validationErr *ValidationError
+	return errors.As(err, &paramErr) || errors.As(err, &validationErr)
+}
+
 func defaultErrorHandler(c Ctx) error {
 	return c.Response().
 		Status(c.Response().Intercept().Status()).
@@ -2,7 +2,9 @@ package cp
 
 import (
 	"fmt"
+	"net"
 	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/creamsensation/gox"
@@ -75,18 +77,13 @@ func (g *generator) Csrf(name string) gox.Node {
 func (g *generator) Link(name string, args ...Map) string {
 	l := g.Lang().Current()
 	for _, r := range *g.routes {
-		if g.config.Localization.Enabled && !g.config.Localization.Path {
-			if r.Name == name {
-				return g.replacePathParamsWithArgs(r.Path, args...)
-			}
+		if r.Name != name {
 			continue
 		}
-		if g.config.Localization.Enabled && r.Name == name && r.Lang == l {
-			return g.replacePathParamsWithArgs(r.Path, args...)
-		}
-		if r.Name == name {
-			return g.replacePathParamsWithArgs(r.Path, args...)
+		if g.config.Localization.Enabled && g.config.Localization.Path && r.Lang != l {
+			continue
 		}
+		return g.createLink(r, args...)
 	}
 	return ""
 }
@@ -128,7 +125,52 @@ func (g *generator) SwitchLang(langCode string) string {
 	return path
 }
 
-func (g *generator) replacePathParamsWithArgs(path string, args ...Map) string {
+func (g *generator) createLink(route *Route, args ...Map) string {
+	params := route.Params
+	if route.host != nil {
+		params = slices.Concat(params, route.host.params)
+	}
+	if len(args) > 0 {
+		for _, param := range params {
+			v, ok := args[0][param.Name]
+			if ok && !param.Matcher.MatchString(fmt.Sprintf("%v", v)) {
+				return ""
+			}
+		}
+	}
+	path := replacePathParamsWithArgs(route.Path, args...)
+	if route.host == nil {
+		return path
+	}
+	host, ok := g.createHost(route.host, args...)
+	if !ok {
+		return ""
+	}
+	return g.Request().Protocol() + "://" + host + path
+}
+
+func (g *generator) createHost(h *host, args ...Map) (string, bool) {
+	replace := make([]string, 0)
+	for _, param := range h.params {
+		value := g.Request().PathValue(param.Name)
+		if len(args) > 0 {
+			if v, ok := args[0][param.Name]; ok {
+				value = fmt.Sprintf("%v", v)
+			}
+		}
+		if !param.Matcher.MatchString(value) {
+			return "", false
+		}
+		replace = append(replace, "{"+param.Name+"}", value)
+	}
+	result := strings.NewReplacer(replace...).Replace(h.template)
+	if _, port, err := net.SplitHostPort(g.Request().Raw().Host); err == nil {
+		result = net.JoinHostPort(result, port)
+	}
+	return result, true
+}
+
+func replacePathParamsWithArgs(path string, args ...Map) string {
 	if len(args) == 0 {
 		return path
 	}
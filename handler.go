@@ -13,14 +13,13 @@ type Handler func(c Ctx) error
 
 type handler struct {
 	core   *core
+	router *router
 	method string
-	path   string
-	name   string
+	route  *Route
 }
 
 func (h handler) create(fn Handler) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
-		matchedRoute := h.matchRoute(r.URL.Path)
 		c := createContext(
 			ctxParam{
 				assets:       h.core.assets,
@@ -28,48 +27,80 @@ func (h handler) create(fn Handler) func(http.ResponseWriter, *http.Request) {
 				layout:       h.core.layout,
 				r:            r,
 				w:            w,
-				matchedRoute: matchedRoute,
+				matchedRoute: h.route,
 				routes:       h.core.router.routes,
+				proxies:      h.core.trustedProxies,
+				services:     h.core.services,
 			},
 		)
+		for _, hook := range h.core.requestHooks {
+			hook(c)
+		}
+		defer h.runResponseHooks(c)
 		if h.core.router.config.Router.Recover {
 			defer h.createRecover(c)
 		}
-		for _, middleware := range h.applyInternalMiddlewares(matchedRoute, h.core.router.middlewares) {
-			c.mu.Lock()
-			c.err = middleware(c)
-			if c.err != nil {
-				c.mu.Unlock()
-				h.createResponse(c)
-				return
-			}
+		matched := h.matchParams(r)
+		if !matched {
+			c.chain = []Handler{h.core.notFoundHandler}
+		}
+		if matched {
+			middlewares := h.applyInternalMiddlewares(h.router.collectMiddlewares(), h.route.Middlewares)
+			c.chain = append(middlewares, h.createRouteHandler(c, fn))
 		}
-		if len(c.response.DataType) == 0 {
-			err := fn(c)
-			if err != nil {
-				c.err = err
-			}
+		c.err = c.Continue()
+		if isBadRequestError(c.err) {
+			h.createBadRequest(c)
 		}
 		h.createResponse(c)
 	}
 }
 
-func (h handler) applyInternalMiddlewares(matchedRoute *Route, middlewares []Handler) []Handler {
+func (h handler) applyInternalMiddlewares(middlewares ...[]Handler) []Handler {
 	r := make([]Handler, 0)
 	if h.core.config.Localization.Enabled {
 		r = append(r, createLangMiddleware())
 	}
-	if h.core.config.Security.Csrf != nil {
+	if h.core.config.Security.Csrf != nil && h.route.Name != wildcardRouteName {
 		r = append(r, createCsrfMiddleware())
 	}
-	if len(matchedRoute.Firewalls) > 0 {
-		r = append(r, createFirewallMiddleware(matchedRoute.Firewalls))
+	if len(h.route.Firewalls) > 0 {
+		r = append(r, createFirewallMiddleware(h.route.Firewalls))
+	}
+	for _, m := range middlewares {
+		r = append(r, m...)
 	}
-	r = append(r, middlewares...)
 	return r
 }
 
+func (h handler) runResponseHooks(c *ctx) {
+	for _, hook := range h.core.responseHooks {
+		hook(c)
+	}
+}
+
+func (h handler) matchParams(r *http.Request) bool {
+	for _, param := range h.route.Params {
+		if !param.Matcher.MatchString(r.PathValue(param.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (h handler) createRouteHandler(c *ctx, fn Handler) Handler {
+	return func(Ctx) error {
+		if len(c.response.DataType) > 0 {
+			return nil
+		}
+		return fn(c)
+	}
+}
+
 func (h handler) createResponse(c *ctx) {
+	if c.response.raw != nil && c.response.raw.written {
+		return
+	}
 	if c.err != nil {
 		c.w.Header().Set(header.ContentType, contentType.Text)
 		if c.response.StatusCode == http.StatusOK {
@@ -104,6 +135,15 @@ func (h handler) createResponse(c *ctx) {
 	}
 }
 
+func (h handler) createBadRequest(c *ctx) {
+	c.response.StatusCode = http.StatusBadRequest
+	err := h.core.errorHandler(c)
+	c.err = nil
+	if err != nil {
+		c.err = err
+	}
+}
+
 func (h handler) createRecover(c *ctx) {
 	if e := recover(); e != nil {
 		err, ok := e.(error)
@@ -122,12 +162,3 @@ func (h handler) createRecover(c *ctx) {
 		h.createResponse(c)
 	}
 }
-
-func (h handler) matchRoute(path string) *Route {
-	for _, r := range *h.core.router.routes {
-		if r.Matcher.MatchString(path) {
-			return r
-		}
-	}
-	return nil
-}
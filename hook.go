@@ -0,0 +1,47 @@
+package cp
+
+import (
+	"context"
+	"errors"
+)
+
+type Hook func(ctx context.Context) error
+
+type ObserverHook func(c Ctx)
+
+func (c *core) OnStart(hooks ...Hook) Creampuff {
+	c.startHooks = append(c.startHooks, hooks...)
+	return c
+}
+
+func (c *core) OnShutdown(hooks ...Hook) Creampuff {
+	c.shutdownHooks = append(c.shutdownHooks, hooks...)
+	return c
+}
+
+func (c *core) OnRequest(hooks ...ObserverHook) Creampuff {
+	c.requestHooks = append(c.requestHooks, hooks...)
+	return c
+}
+
+func (c *core) OnResponse(hooks ...ObserverHook) Creampuff {
+	c.responseHooks = append(c.responseHooks, hooks...)
+	return c
+}
+
+func (c *core) runStartHooks(ctx context.Context) error {
+	for _, hook := range c.startHooks {
+		if err := hook(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *core) runShutdownHooks(ctx context.Context) error {
+	errs := make([]error, 0)
+	for i := len(c.shutdownHooks) - 1; i >= 0; i-- {
+		errs = append(errs, c.shutdownHooks[i](ctx))
+	}
+	return errors.Join(errs...)
+}
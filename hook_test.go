@@ -0,0 +1,33 @@
+package cp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHookObservers(t *testing.T) {
+	app := TestApp(t.TempDir())
+	calls := make([]string, 0)
+	app.OnRequest(
+		func(c Ctx) {
+			calls = append(calls, "request "+c.Request().Path())
+		},
+	)
+	app.OnResponse(
+		func(c Ctx) {
+			calls = append(calls, "response "+c.Request().Name())
+		},
+	)
+	app.Get(
+		"/", func(c Ctx) error {
+			calls = append(calls, "handler")
+			return c.Response().Text("ok")
+		}, Name("home"),
+	)
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, []string{"request /", "handler", "response home"}, calls)
+}
@@ -0,0 +1,90 @@
+package cp
+
+import (
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+type host struct {
+	pattern  string
+	template string
+	matcher  *regexp.Regexp
+	params   []RouteParam
+	mux      *http.ServeMux
+}
+
+const (
+	hostParamRegex = `[^.]+`
+)
+
+func createHost(pattern string) *host {
+	h := &host{
+		pattern: pattern,
+		params:  make([]RouteParam, 0),
+	}
+	parts := strings.Split(pattern, ".")
+	res := make([]string, len(parts))
+	for i, part := range parts {
+		if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") {
+			res[i] = regexp.QuoteMeta(part)
+			continue
+		}
+		name, p, _ := strings.Cut(part[1:len(part)-1], ":")
+		parts[i] = "{" + name + "}"
+		if len(p) == 0 {
+			p = hostParamRegex
+		}
+		if t, ok := paramTypes[p]; ok {
+			p = t
+		}
+		h.params = append(
+			h.params, RouteParam{
+				Name:    name,
+				Pattern: p,
+				Matcher: regexp.MustCompile("^(?:" + p + ")$"),
+			},
+		)
+		res[i] = "(" + p + ")"
+	}
+	h.template = strings.Join(parts, ".")
+	h.matcher = regexp.MustCompile("^" + strings.Join(res, `\.`) + "$")
+	if len(h.params) > 0 {
+		h.mux = http.NewServeMux()
+	}
+	return h
+}
+
+func (h *host) match(r *http.Request) bool {
+	matches := h.matcher.FindStringSubmatch(parseHostname(r.Host))
+	if matches == nil {
+		return false
+	}
+	for i, param := range h.params {
+		r.SetPathValue(param.Name, matches[i+1])
+	}
+	return true
+}
+
+func (h *host) isDynamic() bool {
+	return h.mux != nil
+}
+
+func formatHostPattern(pattern string) string {
+	parts := strings.Split(pattern, ".")
+	for i, part := range parts {
+		if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") {
+			parts[i] = strings.ToLower(part)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+func parseHostname(value string) string {
+	hostname, _, err := net.SplitHostPort(value)
+	if err != nil {
+		return value
+	}
+	return hostname
+}
@@ -11,12 +11,12 @@ const (
 
 const (
 	namePrefixDivider = "_"
+	headerAllow       = "Allow"
 )
 
 var (
-	httpMethods = []string{
-		http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
-		http.MethodOptions, http.MethodHead, http.MethodConnect, http.MethodTrace,
+	defaultHttpMethods = []string{
+		http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead,
 	}
 )
 
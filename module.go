@@ -0,0 +1,49 @@
+package cp
+
+type Module interface {
+	Register(app Creampuff)
+}
+
+type PrefixedModule interface {
+	Module
+	Prefix() any
+}
+
+type NamedModule interface {
+	Module
+	Name() string
+}
+
+type moduleApp struct {
+	*router
+	*core
+}
+
+func (c *core) Module(modules ...Module) Creampuff {
+	c.registerModules(c.router, modules)
+	return c
+}
+
+func (a moduleApp) Module(modules ...Module) Creampuff {
+	a.core.registerModules(a.router, modules)
+	return a
+}
+
+func (c *core) registerModules(r *router, modules []Module) {
+	for _, m := range modules {
+		var prefix any = ""
+		var name string
+		if pm, ok := m.(PrefixedModule); ok {
+			prefix = pm.Prefix()
+		}
+		if nm, ok := m.(NamedModule); ok {
+			name = nm.Name()
+		}
+		m.Register(
+			moduleApp{
+				router: r.Group(prefix, name).(*router),
+				core:   c,
+			},
+		)
+	}
+}
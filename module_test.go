@@ -0,0 +1,86 @@
+package cp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	
+	"github.com/stretchr/testify/assert"
+	
+	"github.com/creamsensation/gox"
+)
+
+type testBlogModule struct{}
+
+func (testBlogModule) Name() string {
+	return "blog"
+}
+
+func (testBlogModule) Prefix() any {
+	return "/blog"
+}
+
+func (testBlogModule) Register(app Creampuff) {
+	app.Layout().Add(
+		"blog", func(c Ctx, nodes ...gox.Node) gox.Node {
+			return gox.Fragment(nodes...)
+		},
+	)
+	app.Use(
+		func(c Ctx) error {
+			c.Response().Header().Set("X-Module", "blog")
+			return c.Continue()
+		},
+	)
+	app.Get(
+		"/", func(c Ctx) error {
+			return c.Response().Text(c.Request().Name())
+		}, Name("index"), LayoutName("blog"),
+	)
+	app.Module(testCommentsModule{})
+}
+
+type testCommentsModule struct{}
+
+func (testCommentsModule) Prefix() any {
+	return "/comments"
+}
+
+func (testCommentsModule) Register(app Creampuff) {
+	app.Get(
+		"/", func(c Ctx) error {
+			return c.Response().Text(c.Request().Name())
+		}, Name("comments"),
+	)
+}
+
+type testPlainModule struct{}
+
+func (testPlainModule) Register(app Creampuff) {
+	app.Get(
+		"/plain/", func(c Ctx) error {
+			return c.Response().Text(c.Request().Name())
+		}, Name("plain"),
+	)
+}
+
+func TestModule(t *testing.T) {
+	app := TestApp(t.TempDir())
+	app.Module(testBlogModule{}, testPlainModule{})
+	assert.NoError(t, app.Validate())
+	for path, expected := range map[string]string{
+		"/blog/":          "blog_index",
+		"/blog/comments/": "blog_comments",
+		"/plain/":         "plain",
+	} {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		assert.Equal(t, expected, w.Body.String(), path)
+		if path != "/plain/" {
+			assert.Equal(t, "blog", w.Header().Get("X-Module"))
+			continue
+		}
+		assert.Empty(t, w.Header().Get("X-Module"))
+	}
+}
@@ -0,0 +1,79 @@
+package cp
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ParamError struct {
+	Name  string
+	Value string
+	Err   error
+}
+
+var (
+	uuidMatcher = regexp.MustCompile("^" + paramTypes["uuid"] + "$")
+)
+
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("%v: %s", e.Err, e.Name)
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+func parseParam[T any](name, value string, parse func(value string) (T, error), defaultValue []T) (T, error) {
+	var zero T
+	if len(value) == 0 {
+		if len(defaultValue) > 0 {
+			return defaultValue[0], nil
+		}
+		return zero, &ParamError{Name: name, Err: ErrorMissingParam}
+	}
+	result, err := parse(value)
+	if err != nil {
+		return zero, &ParamError{Name: name, Value: value, Err: ErrorInvalidParam}
+	}
+	return result, nil
+}
+
+func parseUUID(value string) (string, error) {
+	if !uuidMatcher.MatchString(value) {
+		return "", ErrorInvalidParam
+	}
+	return strings.ToLower(value), nil
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "on":
+		return true, nil
+	case "off":
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+func parseTime(layout string) func(value string) (time.Time, error) {
+	return func(value string) (time.Time, error) {
+		return time.Parse(layout, value)
+	}
+}
+
+func splitParamValues(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if len(item) == 0 {
+				continue
+			}
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,134 @@
+package cp
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+type proxyConfig struct {
+	networks []*net.IPNet
+	header   string
+}
+
+const (
+	ProxyHeaderForwarded    = "Forwarded"
+	ProxyHeaderForwardedFor = "X-Forwarded-For"
+	ProxyHeaderRealIp       = "X-Real-IP"
+)
+
+func parseTrustedProxies(values []string) ([]*net.IPNet, error) {
+	result := make([]*net.IPNet, 0, len(values))
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("%w: %s", ErrorInvalidProxy, value)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			result = append(result, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrorInvalidProxy, value)
+		}
+		result = append(result, network)
+	}
+	return result, nil
+}
+
+func createProxyConfig(values []string, header string) (proxyConfig, error) {
+	if len(header) == 0 {
+		header = ProxyHeaderForwardedFor
+	}
+	for _, name := range []string{ProxyHeaderForwarded, ProxyHeaderForwardedFor, ProxyHeaderRealIp} {
+		if !strings.EqualFold(name, header) {
+			continue
+		}
+		networks, err := parseTrustedProxies(values)
+		if err != nil {
+			return proxyConfig{}, err
+		}
+		return proxyConfig{networks: networks, header: name}, nil
+	}
+	return proxyConfig{}, fmt.Errorf("%w: %s", ErrorInvalidProxyHeader, header)
+}
+
+func isTrustedProxy(proxies proxyConfig, remoteAddr string) bool {
+	return isTrustedIp(proxies.networks, net.ParseIP(parseHostname(remoteAddr)))
+}
+
+func isTrustedIp(proxies []*net.IPNet, ip net.IP) bool {
+	if len(proxies) == 0 || ip == nil {
+		return false
+	}
+	for _, proxy := range proxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func resolveClientIp(proxies proxyConfig, r *http.Request) string {
+	remote := net.ParseIP(parseHostname(r.RemoteAddr))
+	if remote == nil {
+		return parseHostname(r.RemoteAddr)
+	}
+	if !isTrustedIp(proxies.networks, remote) {
+		return remote.String()
+	}
+	ip := remote
+	chain := parseForwardedChain(r.Header, proxies.header)
+	for i := len(chain) - 1; i >= 0; i-- {
+		hop := parseForwardedAddress(chain[i])
+		if hop == nil {
+			break
+		}
+		ip = hop
+		if !isTrustedIp(proxies.networks, hop) {
+			break
+		}
+	}
+	return ip.String()
+}
+
+func parseForwardedChain(h http.Header, name string) []string {
+	result := make([]string, 0)
+	for _, value := range h.Values(name) {
+		for _, element := range strings.Split(value, ",") {
+			if name != ProxyHeaderForwarded {
+				result = append(result, element)
+				continue
+			}
+			for _, pair := range strings.Split(element, ";") {
+				key, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok && strings.EqualFold(key, "for") {
+					result = append(result, v)
+				}
+			}
+		}
+	}
+	return result
+}
+
+func parseForwardedAddress(value string) net.IP {
+	value = strings.Trim(strings.TrimSpace(value), `"`)
+	if strings.HasPrefix(value, "[") {
+		end := strings.Index(value, "]")
+		if end < 0 {
+			return nil
+		}
+		return net.ParseIP(value[1:end])
+	}
+	if strings.Count(value, ":") == 1 {
+		value, _, _ = strings.Cut(value, ":")
+	}
+	return net.ParseIP(value)
+}
@@ -0,0 +1,82 @@
+package cp
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+func (r *router) Redirect(from, to any, status ...int) Router {
+	statusCode := http.StatusMovedPermanently
+	if len(status) > 0 {
+		statusCode = status[0]
+	}
+	params := make([]string, 0)
+	switch v := from.(type) {
+	case string:
+		params = parsePathParamNames(v)
+	case map[string]string:
+		for _, p := range v {
+			params = append(params, parsePathParamNames(p)...)
+		}
+	}
+	return r.Route(from, r.createRedirectHandler(to, params, statusCode), Method(http.MethodGet))
+}
+
+func (r *router) Redirects(redirects map[string]string, status ...int) Router {
+	paths := make([]string, 0, len(redirects))
+	for from := range redirects {
+		paths = append(paths, from)
+	}
+	slices.Sort(paths)
+	for _, from := range paths {
+		r.Redirect(from, redirects[from], status...)
+	}
+	return r
+}
+
+func (r *router) createRedirectHandler(to any, params []string, statusCode int) Handler {
+	return func(c Ctx) error {
+		target := r.resolveRedirectTarget(c, to, params)
+		if len(target) == 0 {
+			return r.core.notFoundHandler(c)
+		}
+		if query := c.Request().Raw().URL.RawQuery; len(query) > 0 && !strings.Contains(target, "?") {
+			target += "?" + query
+		}
+		return c.Response().Status(statusCode).Redirect(target)
+	}
+}
+
+func (r *router) resolveRedirectTarget(c Ctx, to any, params []string) string {
+	var target string
+	switch v := to.(type) {
+	case string:
+		target = v
+	case map[string]string:
+		target = v[c.Lang().Current()]
+	}
+	if len(target) == 0 {
+		return ""
+	}
+	args := Map{}
+	for _, name := range params {
+		args[name] = c.Request().PathValue(name)
+	}
+	if strings.HasPrefix(target, "/") || strings.Contains(target, "://") {
+		return replacePathParamsWithArgs(target, args)
+	}
+	return c.Generate().Link(target, args)
+}
+
+func parsePathParamNames(path string) []string {
+	result := make([]string, 0)
+	for _, part := range strings.Split(path, "/") {
+		if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") {
+			continue
+		}
+		name, _, _ := strings.Cut(part[1:len(part)-1], ":")
+		result = append(result, strings.TrimSuffix(name, "..."))
+	}
+	return result
+}
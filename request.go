@@ -3,6 +3,9 @@ package cp
 import (
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 	
 	"github.com/creamsensation/hx"
 	
@@ -20,8 +23,14 @@ type Request interface {
 	Name() string
 	Origin() string
 	Path() string
+	PathInt(key string, defaultValue ...int) (int, error)
+	PathUUID(key string, defaultValue ...string) (string, error)
 	PathValue(key string, defaultValue ...string) string
+	QueryBool(key string, defaultValue ...bool) (bool, error)
+	QueryInt(key string, defaultValue ...int) (int, error)
 	QueryParam(key string, defaultValue ...string) string
+	QuerySlice(key string, defaultValue ...string) []string
+	QueryTime(key, layout string, defaultValue ...time.Time) (time.Time, error)
 	Protocol() string
 	Raw() *http.Request
 	UserAgent() string
@@ -42,10 +51,15 @@ type RequestIs interface {
 }
 
 type request struct {
-	r     *http.Request
-	route *Route
+	r       *http.Request
+	route   *Route
+	proxies proxyConfig
 }
 
+const (
+	headerForwardedProto = "X-Forwarded-Proto"
+)
+
 func (r request) ContentType() string {
 	return r.r.Header.Get(header.ContentType)
 }
@@ -63,7 +77,7 @@ func (r request) Host() string {
 }
 
 func (r request) Ip() string {
-	return r.r.Header.Get("X-Forwarded-For")
+	return resolveClientIp(r.proxies, r.r)
 }
 
 func (r request) Is() RequestIs {
@@ -86,6 +100,14 @@ func (r request) Path() string {
 	return r.r.URL.Path
 }
 
+func (r request) PathInt(key string, defaultValue ...int) (int, error) {
+	return parseParam(key, r.r.PathValue(key), strconv.Atoi, defaultValue)
+}
+
+func (r request) PathUUID(key string, defaultValue ...string) (string, error) {
+	return parseParam(key, r.r.PathValue(key), parseUUID, defaultValue)
+}
+
 func (r request) PathValue(key string, defaultValue ...string) string {
 	value := r.r.PathValue(key)
 	if len(value) == 0 && len(defaultValue) > 0 {
@@ -94,6 +116,14 @@ func (r request) PathValue(key string, defaultValue ...string) string {
 	return value
 }
 
+func (r request) QueryBool(key string, defaultValue ...bool) (bool, error) {
+	return parseParam(key, r.r.URL.Query().Get(key), parseBool, defaultValue)
+}
+
+func (r request) QueryInt(key string, defaultValue ...int) (int, error) {
+	return parseParam(key, r.r.URL.Query().Get(key), strconv.Atoi, defaultValue)
+}
+
 func (r request) QueryParam(key string, defaultValue ...string) string {
 	value := r.r.URL.Query().Get(key)
 	if len(value) == 0 && len(defaultValue) > 0 {
@@ -102,11 +132,30 @@ func (r request) QueryParam(key string, defaultValue ...string) string {
 	return value
 }
 
+func (r request) QuerySlice(key string, defaultValue ...string) []string {
+	values := splitParamValues(r.r.URL.Query()[key])
+	if len(values) == 0 {
+		return defaultValue
+	}
+	return values
+}
+
+func (r request) QueryTime(key, layout string, defaultValue ...time.Time) (time.Time, error) {
+	return parseParam(key, r.r.URL.Query().Get(key), parseTime(layout), defaultValue)
+}
+
 func (r request) Protocol() string {
-	if r.r.TLS == nil {
+	if r.r.TLS != nil {
+		return "https"
+	}
+	if !isTrustedProxy(r.proxies, r.r.RemoteAddr) {
 		return "http"
 	}
-	return "https"
+	proto, _, _ := strings.Cut(r.r.Header.Get(headerForwardedProto), ",")
+	if strings.EqualFold(strings.TrimSpace(proto), "https") {
+		return "https"
+	}
+	return "http"
 }
 
 func (r request) Raw() *http.Request {
@@ -0,0 +1,152 @@
+package cp
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+	
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestProtocol(t *testing.T) {
+	proxies, err := createProxyConfig([]string{"10.0.0.0/8", "192.168.1.1"}, "")
+	assert.NoError(t, err)
+	for _, item := range []struct {
+		remoteAddr string
+		proto      string
+		tls        bool
+		expected   string
+	}{
+		{"10.1.2.3:1234", "https", false, "https"},
+		{"192.168.1.1:1234", "https, http", false, "https"},
+		{"203.0.113.5:1234", "https", false, "http"},
+		{"203.0.113.5:1234", "", true, "https"},
+		{"10.1.2.3:1234", "", false, "http"},
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = item.remoteAddr
+		r.Header.Set("X-Forwarded-Proto", item.proto)
+		if item.tls {
+			r.TLS = &tls.ConnectionState{}
+		}
+		req := request{r: r, proxies: proxies}
+		assert.Equal(t, item.expected, req.Protocol(), item.remoteAddr)
+		assert.Equal(t, item.expected+"://example.com", req.Host(), item.remoteAddr)
+	}
+	_, err = createProxyConfig([]string{"invalid"}, "")
+	assert.ErrorIs(t, err, ErrorInvalidProxy)
+	_, err = createProxyConfig([]string{"10.0.0.1"}, "X-Client-IP")
+	assert.ErrorIs(t, err, ErrorInvalidProxyHeader)
+}
+
+func TestRequestIp(t *testing.T) {
+	networks := []string{"10.0.0.0/8", "2001:db8::/32"}
+	for _, item := range []struct {
+		name        string
+		proxyHeader string
+		remoteAddr  string
+		headers     map[string]string
+		expected    string
+	}{
+		{"direct", "", "203.0.113.5:1234", nil, "203.0.113.5"},
+		{"spoofed", "", "203.0.113.5:1234", map[string]string{"X-Forwarded-For": "1.2.3.4"}, "203.0.113.5"},
+		{
+			"forwarded for", "", "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "1.2.3.4, 198.51.100.7, 10.0.0.2"},
+			"198.51.100.7",
+		},
+		{"real ip", "x-real-ip", "10.0.0.1:1234", map[string]string{"X-Real-IP": "198.51.100.7"}, "198.51.100.7"},
+		{
+			"forwarded", "Forwarded", "10.0.0.1:1234",
+			map[string]string{"Forwarded": `for=192.0.2.60;proto=https, for="[2001:db8::1]:4711"`}, "192.0.2.60",
+		},
+		{"forwarded port", "Forwarded", "10.0.0.1:1234", map[string]string{"Forwarded": "for=192.0.2.60:8080"}, "192.0.2.60"},
+		{"invalid hop", "", "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "198.51.100.7, unknown"}, "10.0.0.1"},
+		{"only proxies", "", "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "10.0.0.3, 10.0.0.2"}, "10.0.0.3"},
+		{"missing header", "", "10.0.0.1:1234", nil, "10.0.0.1"},
+		{"unconfigured header", "", "10.0.0.1:1234", map[string]string{"X-Real-IP": "198.51.100.7"}, "10.0.0.1"},
+		{
+			"spoofed forwarded", "", "10.0.0.1:1234",
+			map[string]string{"Forwarded": "for=1.2.3.4", "X-Forwarded-For": "198.51.100.7"}, "198.51.100.7",
+		},
+		{
+			"spoofed forwarded for", "Forwarded", "10.0.0.1:1234",
+			map[string]string{"Forwarded": "for=198.51.100.7", "X-Forwarded-For": "1.2.3.4"}, "198.51.100.7",
+		},
+	} {
+		proxies, err := createProxyConfig(networks, item.proxyHeader)
+		assert.NoError(t, err)
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = item.remoteAddr
+		for name, value := range item.headers {
+			r.Header.Set(name, value)
+		}
+		assert.Equal(t, item.expected, request{r: r, proxies: proxies}.Ip(), item.name)
+	}
+}
+
+func TestRequestParams(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/?page=2&page_invalid=x&active=on&tags=a,b&tags=c&from=2024-05-01", nil)
+	r.SetPathValue("id", "42")
+	r.SetPathValue("uuid", "8F14E45F-CEEA-467F-A0A6-1DDBB4D3A5C1")
+	req := request{r: r}
+	t.Run(
+		"path", func(t *testing.T) {
+			id, err := req.PathInt("id")
+			assert.NoError(t, err)
+			assert.Equal(t, 42, id)
+			uuid, err := req.PathUUID("uuid")
+			assert.NoError(t, err)
+			assert.Equal(t, "8f14e45f-ceea-467f-a0a6-1ddbb4d3a5c1", uuid)
+			_, err = req.PathUUID("id")
+			assert.ErrorIs(t, err, ErrorInvalidParam)
+		},
+	)
+	t.Run(
+		"query", func(t *testing.T) {
+			page, err := req.QueryInt("page")
+			assert.NoError(t, err)
+			assert.Equal(t, 2, page)
+			limit, err := req.QueryInt("limit", 20)
+			assert.NoError(t, err)
+			assert.Equal(t, 20, limit)
+			_, err = req.QueryInt("page_invalid", 1)
+			assert.ErrorIs(t, err, ErrorInvalidParam)
+			_, err = req.QueryInt("limit")
+			assert.ErrorIs(t, err, ErrorMissingParam)
+			active, err := req.QueryBool("active")
+			assert.NoError(t, err)
+			assert.True(t, active)
+			from, err := req.QueryTime("from", time.DateOnly)
+			assert.NoError(t, err)
+			assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), from)
+			assert.Equal(t, []string{"a", "b", "c"}, req.QuerySlice("tags"))
+			assert.Equal(t, []string{"x"}, req.QuerySlice("missing", "x"))
+		},
+	)
+	t.Run(
+		"bad request", func(t *testing.T) {
+			app := TestApp(t.TempDir())
+			app.Get(
+				"/items/{id}/", func(c Ctx) error {
+					id, err := c.Request().PathInt("id")
+					if err != nil {
+						return err
+					}
+					return c.Response().Text(strconv.Itoa(id))
+				},
+			)
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/abc/", nil))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, "invalid parameter: id", w.Body.String())
+			
+			w = httptest.NewRecorder()
+			app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/7/", nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "7", w.Body.String())
+		},
+	)
+}
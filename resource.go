@@ -0,0 +1,52 @@
+package cp
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+type resourceAction struct {
+	method  string
+	path    string
+	methods []string
+}
+
+var (
+	resourceActions = []resourceAction{
+		{method: "Index", path: "/", methods: []string{http.MethodGet}},
+		{method: "Create", path: "/create/", methods: []string{http.MethodGet}},
+		{method: "Store", path: "/", methods: []string{http.MethodPost}},
+		{method: "Show", path: "/{id}/", methods: []string{http.MethodGet}},
+		{method: "Edit", path: "/{id}/edit/", methods: []string{http.MethodGet}},
+		{method: "Update", path: "/{id}/", methods: []string{http.MethodPut, http.MethodPatch}},
+		{method: "Destroy", path: "/{id}/", methods: []string{http.MethodDelete}},
+	}
+)
+
+func (r *router) Resource(name string, path any, controller any, config ...RouteConfig) Router {
+	v := reflect.ValueOf(controller)
+	for _, action := range resourceActions {
+		method := v.MethodByName(action.method)
+		if !method.IsValid() {
+			continue
+		}
+		fn, ok := method.Interface().(func(Ctx) error)
+		if !ok {
+			continue
+		}
+		r.Route(
+			r.joinPathSuffix(path, action.path),
+			fn,
+			slices.Concat(
+				config,
+				[]RouteConfig{
+					Method(action.methods...),
+					Name(name + namePrefixDivider + strings.ToLower(action.method)),
+				},
+			)...,
+		)
+	}
+	return r
+}
@@ -1,6 +1,8 @@
 package cp
 
 import (
+	"net/http"
+	
 	"github.com/creamsensation/gox"
 	
 	"github.com/creamsensation/sender"
@@ -8,6 +10,8 @@ import (
 
 type Response interface {
 	sender.ExtendableSend
+	Header() http.Header
+	Raw() http.ResponseWriter
 	Status(statusCode int) Response
 	Refresh() error
 	Layout(name string) Response
@@ -20,6 +24,12 @@ type response struct {
 	ctx    *ctx
 	layout *layout
 	l      layoutFactory
+	raw    *rawWriter
+}
+
+type rawWriter struct {
+	http.ResponseWriter
+	written bool
 }
 
 func (r *response) Refresh() error {
@@ -38,6 +48,17 @@ func (r *response) Layout(name string) Response {
 	return r
 }
 
+func (r *response) Header() http.Header {
+	return r.ctx.w.Header()
+}
+
+func (r *response) Raw() http.ResponseWriter {
+	if r.raw == nil {
+		r.raw = &rawWriter{ResponseWriter: r.ctx.w}
+	}
+	return r.raw
+}
+
 func (r *response) Status(statusCode int) Response {
 	r.StatusCode = statusCode
 	return r
@@ -56,3 +77,17 @@ func (r *response) Intercept() Intercept {
 		err:    r.ctx.err,
 	}
 }
+
+func (w *rawWriter) WriteHeader(statusCode int) {
+	w.written = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *rawWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *rawWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
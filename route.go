@@ -12,19 +12,40 @@ type RouteConfig struct {
 }
 
 type Route struct {
-	Lang      string
-	Path      string
-	Name      string
-	Matcher   *regexp.Regexp
-	Methods   []string
-	Firewalls []firewall.Firewall
+	Lang        string
+	Host        string
+	Path        string
+	Name        string
+	Layout      string
+	Matcher     *regexp.Regexp
+	Params      []RouteParam
+	Methods     []string
+	Firewalls   []firewall.Firewall
+	Middlewares []Handler
+	router      *router
+	host        *host
+}
+
+type RouteParam struct {
+	Name    string
+	Pattern string
+	Matcher *regexp.Regexp
 }
 
 const (
 	routeMethod = iota
 	routeName
+	routeMiddleware
+	routeLayout
 )
 
+func (r Route) MiddlewareCount() int {
+	if r.router == nil {
+		return len(r.Middlewares)
+	}
+	return len(r.router.collectMiddlewares()) + len(r.Middlewares)
+}
+
 func Method(method ...string) RouteConfig {
 	return RouteConfig{
 		Type:  routeMethod,
@@ -38,3 +59,17 @@ func Name(name string) RouteConfig {
 		Value: name,
 	}
 }
+
+func LayoutName(name string) RouteConfig {
+	return RouteConfig{
+		Type:  routeLayout,
+		Value: name,
+	}
+}
+
+func Middleware(handlers ...Handler) RouteConfig {
+	return RouteConfig{
+		Type:  routeMiddleware,
+		Value: handlers,
+	}
+}
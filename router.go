@@ -1,9 +1,12 @@
 package cp
 
 import (
+	"fmt"
+	"log"
 	"net/http"
 	"net/url"
 	"regexp"
+	"slices"
 	"strings"
 	
 	"github.com/creamsensation/config"
@@ -13,21 +16,51 @@ import (
 type Router interface {
 	Static(path, dir string) Router
 	Route(path any, handler Handler, config ...RouteConfig) Router
+	Get(path any, handler Handler, config ...RouteConfig) Router
+	Post(path any, handler Handler, config ...RouteConfig) Router
+	Put(path any, handler Handler, config ...RouteConfig) Router
+	Patch(path any, handler Handler, config ...RouteConfig) Router
+	Delete(path any, handler Handler, config ...RouteConfig) Router
+	Any(path any, handler Handler, config ...RouteConfig) Router
+	Resource(name string, path any, controller any, config ...RouteConfig) Router
+	Handle(path any, handler http.Handler, config ...RouteConfig) Router
+	Mount(prefix any, handler http.Handler, config ...RouteConfig) Router
+	Redirect(from, to any, status ...int) Router
+	Redirects(redirects map[string]string, status ...int) Router
+	TrailingSlash(policy int) Router
 	Group(path any, name ...string) Router
+	Host(pattern string) Router
+	Use(handlers ...Handler) Router
 }
 
 type router struct {
-	core        *core
-	config      config.Config
-	mux         *http.ServeMux
-	prefix      config.Prefix
-	middlewares []Handler
-	assets      *assets
-	routes      *[]*Route
+	core          *core
+	parent        *router
+	host          *host
+	config        config.Config
+	mux           *http.ServeMux
+	prefix        config.Prefix
+	middlewares   []Handler
+	assets        *assets
+	routes        *[]*Route
+	fallback      http.HandlerFunc
+	trailingSlash int
 }
 
 const (
-	paramRegex = `[0-9a-zA-Z]+`
+	paramRegex         = `[^/]+`
+	wildcardParamRegex = `.*`
+	wildcardRouteName  = "wildcard"
+	mountPathParam     = "path"
+)
+
+var (
+	paramTypes = map[string]string{
+		"int":   `[0-9]+`,
+		"alpha": `[a-zA-Z]+`,
+		"slug":  `[a-z0-9]+(?:-[a-z0-9]+)*`,
+		"uuid":  `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`,
+	}
 )
 
 func (r *router) Static(path, dir string) Router {
@@ -54,6 +87,55 @@ func (r *router) Route(path any, fn Handler, config ...RouteConfig) Router {
 	return r
 }
 
+func (r *router) Get(path any, fn Handler, config ...RouteConfig) Router {
+	return r.Route(path, fn, slices.Concat(config, []RouteConfig{Method(http.MethodGet)})...)
+}
+
+func (r *router) Post(path any, fn Handler, config ...RouteConfig) Router {
+	return r.Route(path, fn, slices.Concat(config, []RouteConfig{Method(http.MethodPost)})...)
+}
+
+func (r *router) Put(path any, fn Handler, config ...RouteConfig) Router {
+	return r.Route(path, fn, slices.Concat(config, []RouteConfig{Method(http.MethodPut)})...)
+}
+
+func (r *router) Patch(path any, fn Handler, config ...RouteConfig) Router {
+	return r.Route(path, fn, slices.Concat(config, []RouteConfig{Method(http.MethodPatch)})...)
+}
+
+func (r *router) Delete(path any, fn Handler, config ...RouteConfig) Router {
+	return r.Route(path, fn, slices.Concat(config, []RouteConfig{Method(http.MethodDelete)})...)
+}
+
+func (r *router) Any(path any, fn Handler, config ...RouteConfig) Router {
+	return r.Route(path, fn, slices.Concat(config, []RouteConfig{Method(defaultHttpMethods...)})...)
+}
+
+func (r *router) Handle(path any, h http.Handler, config ...RouteConfig) Router {
+	return r.Route(
+		path, func(c Ctx) error {
+			h.ServeHTTP(c.Response().Raw(), c.Request().Raw())
+			return nil
+		}, createHandlerRouteConfig(config, false)...,
+	)
+}
+
+func (r *router) Mount(prefix any, h http.Handler, config ...RouteConfig) Router {
+	return r.Route(
+		r.joinPathSuffix(prefix, "/{"+mountPathParam+"...}"), func(c Ctx) error {
+			req := c.Request().Raw()
+			u := *req.URL
+			u.Path = "/" + req.PathValue(mountPathParam)
+			u.RawPath = ""
+			mr := new(http.Request)
+			*mr = *req
+			mr.URL = &u
+			h.ServeHTTP(c.Response().Raw(), mr)
+			return nil
+		}, createHandlerRouteConfig(config, true)...,
+	)
+}
+
 func (r *router) Group(path any, name ...string) Router {
 	var routerName string
 	if len(name) > 0 {
@@ -67,39 +149,101 @@ func (r *router) Group(path any, name ...string) Router {
 			Path: r.mergePrefixPath(r.prefix.Path, path),
 			Name: r.prefix.Name + routerName,
 		},
-		middlewares: r.middlewares,
-		assets:      r.assets,
-		routes:      r.routes,
+		parent:        r,
+		host:          r.host,
+		middlewares:   make([]Handler, 0),
+		assets:        r.assets,
+		routes:        r.routes,
+		fallback:      r.fallback,
+		trailingSlash: r.trailingSlash,
 	}
 }
 
-func (r *router) createGetWildcardRoute() {
-	method := http.MethodGet
-	path := "/{path...}"
-	r.mux.HandleFunc(
-		r.createRoutePattern(method, path),
-		r.createHandler(
-			method, path, "wildcard", func(c Ctx) error {
-				c.Response().Status(http.StatusNotFound)
-				return r.core.errorHandler(c)
-			},
-		),
-	)
+func (r *router) Host(pattern string) Router {
+	pattern = formatHostPattern(pattern)
+	hr := &router{
+		core:          r.core,
+		config:        r.config,
+		mux:           r.mux,
+		prefix:        r.prefix,
+		parent:        r,
+		middlewares:   make([]Handler, 0),
+		assets:        r.assets,
+		routes:        r.routes,
+		fallback:      r.fallback,
+		trailingSlash: r.trailingSlash,
+	}
+	for _, h := range r.core.hosts {
+		if h.pattern == pattern {
+			hr.host = h
+			if h.isDynamic() {
+				hr.mux = h.mux
+			}
+			return hr
+		}
+	}
+	hr.host = createHost(pattern)
+	if !hr.host.isDynamic() {
+		r.core.hosts = slices.Insert(r.core.hosts, 0, hr.host)
+		return hr
+	}
+	r.core.hosts = append(r.core.hosts, hr.host)
+	hr.mux = hr.host.mux
+	hr.createWildcardRoute()
+	return hr
+}
+
+func (r *router) Use(handlers ...Handler) Router {
+	r.middlewares = append(r.middlewares, handlers...)
+	return r
+}
+
+func (r *router) createWildcardRoute() {
+	route := &Route{
+		Path:    "/{path...}",
+		Name:    wildcardRouteName,
+		Methods: make([]string, 0),
+	}
+	r.fallback = r.createHandler("", route, r.core.createFallbackHandler(r))
+	r.mux.HandleFunc(r.createHostPattern()+route.Path, r.fallback)
+}
+
+func createHandlerRouteConfig(config []RouteConfig, options bool) []RouteConfig {
+	result := slices.Clone(config)
+	index := -1
+	for i, cfg := range result {
+		if cfg.Type == routeMethod {
+			index = i
+		}
+	}
+	if index < 0 {
+		return append(result, Method(append(slices.Clone(defaultHttpMethods), http.MethodOptions)...))
+	}
+	methods := result[index].Value.([]string)
+	if options && !slices.Contains(methods, http.MethodOptions) {
+		result[index] = Method(append(slices.Clone(methods), http.MethodOptions)...)
+	}
+	return result
 }
 
 func (r *router) createRoute(path string, fn Handler, lang string, config ...RouteConfig) {
-	var name string
+	var name, layoutName string
 	methods := make([]string, 0)
+	middlewares := make([]Handler, 0)
 	for _, cfg := range config {
 		switch cfg.Type {
 		case routeMethod:
 			methods = cfg.Value.([]string)
 		case routeName:
 			name = cfg.Value.(string)
+		case routeMiddleware:
+			middlewares = append(middlewares, cfg.Value.([]Handler)...)
+		case routeLayout:
+			layoutName = cfg.Value.(string)
 		}
 	}
 	if len(methods) == 0 {
-		methods = append(methods, httpMethods...)
+		methods = append(methods, defaultHttpMethods...)
 	}
 	if r.prefix.Path != nil {
 		switch v := r.prefix.Path.(type) {
@@ -112,28 +256,71 @@ func (r *router) createRoute(path string, fn Handler, lang string, config ...Rou
 			}
 		}
 	}
-	path = r.prefixPathWithLangIfEnabled(path, lang)
+	path, params := r.parsePathParams(r.prefixPathWithLangIfEnabled(path, lang))
+	path = r.canonicalizePath(path)
 	if len(r.prefix.Name) > 0 {
 		name = r.prefix.Name + namePrefixDivider + name
 	}
-	*r.routes = append(
-		*r.routes, &Route{
-			Lang:      lang,
-			Path:      path,
-			Name:      name,
-			Methods:   methods,
-			Matcher:   r.createMatcher(path),
-			Firewalls: r.createFirewalls(path, name),
-		},
-	)
+	route := &Route{
+		Lang:        lang,
+		Path:        path,
+		Name:        name,
+		Layout:      layoutName,
+		Methods:     methods,
+		Matcher:     r.createMatcher(path, params),
+		Params:      params,
+		Firewalls:   r.createFirewalls(path, name),
+		Middlewares: middlewares,
+		router:      r,
+	}
+	if r.host != nil {
+		route.Host = r.host.pattern
+		route.host = r.host
+	}
+	if len(lang) > 0 && !r.languageExists(lang) {
+		r.core.issues = append(r.core.issues, fmt.Errorf("%w: route %s uses %s", ErrorInvalidLanguage, path, lang))
+	}
+	*r.routes = append(*r.routes, route)
 	for _, method := range methods {
-		r.mux.HandleFunc(
-			r.createRoutePattern(method, path),
-			r.createHandler(method, path, name, fn),
-		)
+		r.handle(r.createRoutePattern(method, path), r.createHandler(method, route, fn), false)
+		if counterpart, ok := r.createCounterpartPath(path); ok {
+			r.handle(r.createRoutePattern(method, counterpart), r.createCounterpartHandler(), true)
+		}
 	}
 }
 
+func (r *router) handle(pattern string, fn http.HandlerFunc, counterpart bool) {
+	key := patternKey{mux: r.mux, pattern: pattern}
+	if existing, ok := r.core.patterns[key]; ok {
+		if counterpart {
+			return
+		}
+		if existing.counterpart {
+			existing.fn = fn
+			existing.counterpart = false
+			return
+		}
+	}
+	defer func() {
+		if e := recover(); e != nil {
+			err := fmt.Errorf("%w: %v", ErrorConflictingRoute, e)
+			log.Println(err)
+			r.core.issues = append(r.core.issues, err)
+		}
+	}()
+	ph := &patternHandler{fn: fn, counterpart: counterpart}
+	r.mux.Handle(pattern, ph)
+	r.core.patterns[key] = ph
+}
+
+func (r *router) languageExists(lang string) bool {
+	return slices.ContainsFunc(
+		r.config.Localization.Languages, func(l config.Language) bool {
+			return l.Code == lang
+		},
+	)
+}
+
 func (r *router) createFirewalls(path, name string) []firewall.Firewall {
 	result := make([]firewall.Firewall, 0)
 	for _, f := range r.config.Security.Firewalls {
@@ -154,33 +341,75 @@ func (r *router) createFirewalls(path, name string) []firewall.Firewall {
 }
 
 func (r *router) createRoutePattern(method, path string) string {
-	return method + " " + r.formatPatternPath(path)
+	return method + " " + r.createHostPattern() + r.formatPatternPath(path)
+}
+
+func (r *router) createHostPattern() string {
+	if r.host == nil || r.host.isDynamic() {
+		return ""
+	}
+	return r.host.pattern
 }
 
-func (r *router) createMatcher(path string) *regexp.Regexp {
+func (r *router) createMatcher(path string, params []RouteParam) *regexp.Regexp {
 	parts := strings.Split(path, "/")
 	res := make([]string, len(parts))
 	for i, part := range parts {
 		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			name := strings.TrimSuffix(strings.TrimPrefix(part, "{"), "}")
 			res[i] = paramRegex
+			if strings.HasSuffix(name, "...") {
+				res[i] = wildcardParamRegex
+			}
+			for _, param := range params {
+				if param.Name == name {
+					res[i] = "(?:" + param.Pattern + ")"
+				}
+			}
 			continue
 		}
-		res[i] = part
+		res[i] = regexp.QuoteMeta(part)
 	}
-	return regexp.MustCompile(strings.Join(res, "/"))
+	return regexp.MustCompile("^" + strings.Join(res, "/") + "$")
+}
+
+func (r *router) parsePathParams(path string) (string, []RouteParam) {
+	params := make([]RouteParam, 0)
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") {
+			continue
+		}
+		name, pattern, ok := strings.Cut(part[1:len(part)-1], ":")
+		if !ok {
+			continue
+		}
+		if t, exists := paramTypes[pattern]; exists {
+			pattern = t
+		}
+		params = append(
+			params, RouteParam{
+				Name:    name,
+				Pattern: pattern,
+				Matcher: regexp.MustCompile("^(?:" + pattern + ")$"),
+			},
+		)
+		parts[i] = "{" + name + "}"
+	}
+	return strings.Join(parts, "/"), params
 }
 
 func (r *router) formatPatternPath(path string) string {
-	if strings.Contains(path, "...") {
+	if strings.Contains(path, "...") || !strings.HasSuffix(path, "/") {
 		return path
 	}
-	if !strings.HasSuffix(path, "/") {
-		return path + "/{$}"
-	}
 	return path + "{$}"
 }
 
 func (r *router) mustJoinPath(basePath string, path string) string {
+	if len(basePath) == 0 {
+		return path
+	}
 	if strings.Contains(path, "...") {
 		return strings.TrimSuffix(basePath, "/") + "/" + strings.TrimPrefix(path, "/")
 	}
@@ -188,9 +417,27 @@ func (r *router) mustJoinPath(basePath string, path string) string {
 	if err != nil {
 		panic(err)
 	}
+	p, err = url.PathUnescape(p)
+	if err != nil {
+		panic(err)
+	}
 	return p
 }
 
+func (r *router) joinPathSuffix(path any, suffix string) any {
+	switch p := path.(type) {
+	case string:
+		return r.mustJoinPath(p, suffix)
+	case map[string]string:
+		result := make(map[string]string, len(p))
+		for l, item := range p {
+			result[l] = r.mustJoinPath(item, suffix)
+		}
+		return result
+	}
+	return path
+}
+
 func (r *router) mergePrefixPath(prefixPath any, path any) any {
 	switch pp := prefixPath.(type) {
 	case string:
@@ -210,6 +457,47 @@ func (r *router) mergePrefixPath(prefixPath any, path any) any {
 	return path
 }
 
+func (r *router) findAllowedMethods(req *http.Request) []string {
+	result := make([]string, 0)
+	for _, route := range *r.routes {
+		if !r.isRouteReachable(route, req) || !route.Matcher.MatchString(req.URL.Path) {
+			continue
+		}
+		for _, method := range route.Methods {
+			if !slices.Contains(result, method) {
+				result = append(result, method)
+			}
+		}
+	}
+	if len(result) == 0 {
+		return result
+	}
+	if slices.Contains(result, http.MethodGet) && !slices.Contains(result, http.MethodHead) {
+		result = append(result, http.MethodHead)
+	}
+	if !slices.Contains(result, http.MethodOptions) {
+		result = append(result, http.MethodOptions)
+	}
+	return result
+}
+
+func (r *router) isRouteReachable(route *Route, req *http.Request) bool {
+	if r.host != nil && r.host.isDynamic() {
+		return route.host == r.host
+	}
+	if route.host == nil {
+		return true
+	}
+	return !route.host.isDynamic() && route.host.matcher.MatchString(parseHostname(req.Host))
+}
+
+func (r *router) collectMiddlewares() []Handler {
+	if r.parent == nil {
+		return r.middlewares
+	}
+	return slices.Concat(r.parent.collectMiddlewares(), r.middlewares)
+}
+
 func (r *router) prefixPathWithLangIfEnabled(path, lang string) string {
 	if r.config.Localization.Path && !strings.HasSuffix(path, "/"+lang+"/") {
 		return r.mustJoinPath("/"+lang+"/", path)
@@ -217,11 +505,11 @@ func (r *router) prefixPathWithLangIfEnabled(path, lang string) string {
 	return path
 }
 
-func (r *router) createHandler(method, path, name string, fn Handler) func(http.ResponseWriter, *http.Request) {
+func (r *router) createHandler(method string, route *Route, fn Handler) http.HandlerFunc {
 	return handler{
 		core:   r.core,
+		router: r,
 		method: method,
-		path:   path,
-		name:   name,
+		route:  route,
 	}.create(fn)
 }
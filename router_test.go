@@ -1,12 +1,23 @@
 package cp
 
 import (
+	"errors"
 	"net/http"
+	"net/http/httptest"
+	"slices"
 	"testing"
 	
 	"github.com/stretchr/testify/assert"
+	
+	"github.com/creamsensation/cache/memory"
+	"github.com/creamsensation/config"
+	"github.com/creamsensation/gox"
 )
 
+func testOk(c Ctx) error {
+	return c.Response().Text("ok")
+}
+
 func TestRouter(t *testing.T) {
 	t.Run(
 		"basic", func(t *testing.T) {
@@ -26,3 +37,596 @@ func TestRouter(t *testing.T) {
 		},
 	)
 }
+
+func TestRouterMiddlewares(t *testing.T) {
+	t.Run(
+		"global and group", func(t *testing.T) {
+			calls := make([]string, 0)
+			trace := func(name string) Handler {
+				return func(c Ctx) error {
+					calls = append(calls, name)
+					return c.Continue()
+				}
+			}
+			app := TestApp(t.TempDir())
+			admin := app.Group("/admin", "admin").Use(trace("admin"))
+			admin.Group("/users").Use(trace("users")).Route("/", testOk, Method(http.MethodGet))
+			app.Group("/blog", "blog").Route("/", testOk, Method(http.MethodGet))
+			app.Use(trace("global"))
+			
+			w := httptest.NewRecorder()
+			app.Mux().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/users/", nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, []string{"global", "admin", "users"}, calls)
+			
+			calls = calls[:0]
+			w = httptest.NewRecorder()
+			app.Mux().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blog/", nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, []string{"global"}, calls)
+		},
+	)
+	t.Run(
+		"post processing", func(t *testing.T) {
+			app := TestApp(t.TempDir())
+			app.Use(
+				func(c Ctx) error {
+					err := c.Continue()
+					c.Response().Header().Set("X-Handled", "true")
+					if errors.Is(err, ErrorInvalidLayout) {
+						return c.Response().Status(http.StatusNotFound).Text("not found")
+					}
+					return err
+				},
+			)
+			app.Route(
+				"/", func(c Ctx) error {
+					return ErrorInvalidLayout
+				}, Method(http.MethodGet),
+			)
+			w := httptest.NewRecorder()
+			app.Mux().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Equal(t, "true", w.Header().Get("X-Handled"))
+			assert.Equal(t, "not found", w.Body.String())
+		},
+	)
+	t.Run(
+		"route", func(t *testing.T) {
+			calls := make([]string, 0)
+			trace := func(name string) Handler {
+				return func(c Ctx) error {
+					calls = append(calls, name)
+					return c.Continue()
+				}
+			}
+			app := TestApp(
+				t.TempDir(), config.Config{
+					Localization: config.Localization{
+						Enabled:   true,
+						Path:      true,
+						Languages: []config.Language{{Code: "cs", Main: true}, {Code: "en"}},
+					},
+				},
+			)
+			app.Use(trace("global"))
+			app.Route(
+				map[string]string{"cs": "/o-nas", "en": "/about"}, testOk,
+				Method(http.MethodGet), Middleware(trace("route")),
+			)
+			app.Route("/contact", testOk, Method(http.MethodGet))
+			for _, path := range []string{"/cs/o-nas/", "/en/about/"} {
+				calls = calls[:0]
+				w := httptest.NewRecorder()
+				app.Mux().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+				assert.Equal(t, http.StatusOK, w.Code)
+				assert.Equal(t, []string{"global", "route"}, calls)
+			}
+			calls = calls[:0]
+			w := httptest.NewRecorder()
+			app.Mux().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/en/contact/", nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, []string{"global"}, calls)
+		},
+	)
+}
+
+func TestRouterMatch(t *testing.T) {
+	name := func(c Ctx) error {
+		return c.Response().Text(c.Request().Name() + ":" + c.Lang().Current())
+	}
+	t.Run(
+		"overlapping", func(t *testing.T) {
+			app := TestApp(t.TempDir())
+			app.Route("/users/{id}/", name, Method(http.MethodGet), Name("user"))
+			app.Group("/admin", "admin").Route("/users/{id}/edit/", name, Method(http.MethodGet), Name("user_edit"))
+			for path, expected := range map[string]string{
+				"/users/5/":            "user:",
+				"/admin/users/5/edit/": "admin_user_edit:",
+			} {
+				w := httptest.NewRecorder()
+				app.Mux().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+				assert.Equal(t, http.StatusOK, w.Code)
+				assert.Equal(t, expected, w.Body.String())
+			}
+		},
+	)
+	t.Run(
+		"localized", func(t *testing.T) {
+			app := TestApp(
+				t.TempDir(), config.Config{
+					Localization: config.Localization{
+						Enabled:   true,
+						Path:      true,
+						Languages: []config.Language{{Code: "cs", Main: true}, {Code: "en"}},
+					},
+				},
+			)
+			app.Route("/", name, Method(http.MethodGet), Name("home"))
+			app.Route(map[string]string{"cs": "/clanky/", "en": "/articles/"}, name, Method(http.MethodGet), Name("articles"))
+			for path, expected := range map[string]string{
+				"/cs/":          "home:cs",
+				"/en/":          "home:en",
+				"/cs/clanky/":   "articles:cs",
+				"/en/articles/": "articles:en",
+			} {
+				w := httptest.NewRecorder()
+				app.Mux().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+				assert.Equal(t, http.StatusOK, w.Code)
+				assert.Equal(t, expected, w.Body.String())
+			}
+		},
+	)
+}
+
+func TestRouterParams(t *testing.T) {
+	app := TestApp(t.TempDir())
+	value := func(key string) Handler {
+		return func(c Ctx) error {
+			return c.Response().Text(c.Request().PathValue(key))
+		}
+	}
+	app.Route("/users/{id:int}/", value("id"), Method(http.MethodGet), Name("user"))
+	app.Route("/posts/{slug:[a-z0-9-]+}/", value("slug"), Method(http.MethodGet), Name("post"))
+	app.Route("/files/{uuid:uuid}/", value("uuid"), Method(http.MethodGet), Name("file"))
+	t.Run(
+		"dispatch", func(t *testing.T) {
+			for path, expected := range map[string]int{
+				"/users/15/":    http.StatusOK,
+				"/users/abc/":   http.StatusNotFound,
+				"/posts/a-b-1/": http.StatusOK,
+				"/posts/A_B/":   http.StatusNotFound,
+				"/files/0b8a3c52-3f5e-4b7c-9a43-5d0c1f6c8e21/": http.StatusOK,
+				"/files/0b8a3c52/": http.StatusNotFound,
+			} {
+				w := httptest.NewRecorder()
+				app.Mux().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+				assert.Equal(t, expected, w.Code, path)
+			}
+		},
+	)
+	t.Run(
+		"metadata", func(t *testing.T) {
+			routes := *app.(*core).router.routes
+			assert.Equal(t, "/users/{id}/", routes[0].Path)
+			assert.Equal(t, "id", routes[0].Params[0].Name)
+			assert.True(t, routes[0].Matcher.MatchString("/users/15/"))
+			assert.False(t, routes[0].Matcher.MatchString("/admin/users/15/"))
+		},
+	)
+	t.Run(
+		"link", func(t *testing.T) {
+			c := TestCtx(
+				TestCtxParam{
+					Config:         config.Config{Cache: config.Cache{Memory: memory.New(t.TempDir())}},
+					Request:        httptest.NewRequest(http.MethodGet, "/", nil),
+					ResponseWriter: httptest.NewRecorder(),
+					Routes:         app.(*core).router.routes,
+				},
+			)
+			assert.Equal(t, "/users/15/", c.Generate().Link("user", Map{"id": 15}))
+			assert.Equal(t, "", c.Generate().Link("user", Map{"id": "abc"}))
+			assert.Equal(t, "/posts/hello-world/", c.Generate().Link("post", Map{"slug": "hello-world"}))
+		},
+	)
+}
+
+func TestRouterMethods(t *testing.T) {
+	app := TestApp(t.TempDir())
+	method := func(c Ctx) error {
+		return c.Response().Text(c.Request().Method())
+	}
+	app.Get("/get/", method)
+	app.Post("/post/", method)
+	app.Put("/put/", method)
+	app.Patch("/patch/", method)
+	app.Delete("/delete/", method)
+	app.Any("/any/", method)
+	app.Route("/default/", method)
+	for _, item := range []struct {
+		method string
+		path   string
+		code   int
+	}{
+		{http.MethodGet, "/get/", http.StatusOK},
+		{http.MethodPost, "/get/", http.StatusMethodNotAllowed},
+		{http.MethodHead, "/get/", http.StatusOK},
+		{http.MethodPost, "/post/", http.StatusOK},
+		{http.MethodPut, "/put/", http.StatusOK},
+		{http.MethodPatch, "/patch/", http.StatusOK},
+		{http.MethodDelete, "/delete/", http.StatusOK},
+		{http.MethodGet, "/delete/", http.StatusMethodNotAllowed},
+		{http.MethodPatch, "/any/", http.StatusOK},
+		{http.MethodTrace, "/any/", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/default/", http.StatusOK},
+		{http.MethodTrace, "/default/", http.StatusMethodNotAllowed},
+	} {
+		w := httptest.NewRecorder()
+		app.Mux().ServeHTTP(w, httptest.NewRequest(item.method, item.path, nil))
+		assert.Equal(t, item.code, w.Code, item.method+" "+item.path)
+	}
+}
+
+func TestRouterFallback(t *testing.T) {
+	app := TestApp(t.TempDir())
+	app.ErrorHandler(
+		func(c Ctx) error {
+			return c.Response().Text("error handler")
+		},
+	)
+	app.Get("/users/{id:int}/", testOk)
+	app.Put("/users/{id:int}/", testOk)
+	t.Run(
+		"method not allowed", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			app.Mux().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users/1/", nil))
+			assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+			assert.Equal(t, "GET, PUT, HEAD, OPTIONS", w.Header().Get("Allow"))
+			assert.Equal(t, "error handler", w.Body.String())
+		},
+	)
+	t.Run(
+		"options", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			app.Mux().ServeHTTP(w, httptest.NewRequest(http.MethodOptions, "/users/1/", nil))
+			assert.Equal(t, http.StatusNoContent, w.Code)
+			assert.Equal(t, "GET, PUT, HEAD, OPTIONS", w.Header().Get("Allow"))
+		},
+	)
+	t.Run(
+		"not found", func(t *testing.T) {
+			for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodOptions} {
+				w := httptest.NewRecorder()
+				app.Mux().ServeHTTP(w, httptest.NewRequest(method, "/users/abc/", nil))
+				assert.Equal(t, http.StatusNotFound, w.Code, method)
+				assert.Empty(t, w.Header().Get("Allow"), method)
+			}
+		},
+	)
+}
+
+func TestRouterRoutes(t *testing.T) {
+	app := TestApp(t.TempDir())
+	next := func(c Ctx) error {
+		return c.Continue()
+	}
+	app.Use(next)
+	app.Get("/", testOk, Name("home"))
+	app.Group("/admin", "admin").Use(next).Post("/users/", testOk, Name("users"), Middleware(next))
+	app.Get("/debug/routes/", app.RoutesHandler())
+	routes := app.Routes()
+	assert.Len(t, routes, 3)
+	assert.Equal(t, "admin_users", routes[1].Name)
+	assert.Equal(t, "/admin/users/", routes[1].Path)
+	assert.Equal(t, []string{http.MethodPost}, routes[1].Methods)
+	assert.Equal(t, 3, routes[1].MiddlewareCount())
+	assert.Equal(t, 1, routes[0].MiddlewareCount())
+	
+	w := httptest.NewRecorder()
+	app.Mux().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/routes/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "admin_users")
+	assert.Contains(t, w.Body.String(), "/admin/users/")
+}
+
+func TestRouterValidate(t *testing.T) {
+	t.Run(
+		"valid", func(t *testing.T) {
+			app := TestApp(t.TempDir())
+			app.Get("/", testOk, Name("home"))
+			app.Get("/about/", testOk, Name("about"), LayoutName("page"))
+			app.Layout().Add("page", func(c Ctx, nodes ...gox.Node) gox.Node { return gox.Fragment(nodes...) })
+			assert.NoError(t, app.Validate())
+		},
+	)
+	t.Run(
+		"invalid", func(t *testing.T) {
+			app := TestApp(
+				t.TempDir(), config.Config{
+					Localization: config.Localization{
+						Enabled:   true,
+						Path:      true,
+						Languages: []config.Language{{Code: "cs", Main: true}},
+					},
+				},
+			)
+			app.Get("/", testOk, Name("home"))
+			app.Get("/home/", testOk, Name("home"))
+			app.Get(map[string]string{"cs": "/o-nas/", "de": "/uber-uns/"}, testOk, Name("about"))
+			app.Get("/users/{id:int}/", testOk, Name("user"))
+			app.Get("/users/{slug:slug}/", testOk, Name("user_slug"), LayoutName("missing"))
+			err := app.Validate()
+			assert.ErrorIs(t, err, ErrorDuplicateRouteName)
+			assert.ErrorIs(t, err, ErrorInvalidLanguage)
+			assert.ErrorIs(t, err, ErrorConflictingRoute)
+			assert.ErrorIs(t, err, ErrorInvalidLayout)
+		},
+	)
+	t.Run(
+		"shared name", func(t *testing.T) {
+			app := TestApp(t.TempDir())
+			app.Get("/login/", testOk, Name("login"))
+			app.Post("/login/", testOk, Name("login"))
+			assert.NoError(t, app.Validate())
+		},
+	)
+}
+
+type testPostsController struct{}
+
+func (testPostsController) Index(c Ctx) error {
+	return c.Response().Text(c.Request().Name())
+}
+
+func (testPostsController) Show(c Ctx) error {
+	return c.Response().Text(c.Request().Name() + ":" + c.Request().PathValue("id"))
+}
+
+func (testPostsController) Update(c Ctx) error {
+	return c.Response().Text(c.Request().Name() + ":" + c.Request().PathValue("id"))
+}
+
+func TestRouterResource(t *testing.T) {
+	app := TestApp(t.TempDir())
+	app.Resource("posts", "/posts", testPostsController{})
+	for _, item := range []struct {
+		method string
+		path   string
+		code   int
+		body   string
+	}{
+		{http.MethodGet, "/posts/", http.StatusOK, "posts_index"},
+		{http.MethodGet, "/posts/5/", http.StatusOK, "posts_show:5"},
+		{http.MethodPut, "/posts/5/", http.StatusOK, "posts_update:5"},
+		{http.MethodPatch, "/posts/5/", http.StatusOK, "posts_update:5"},
+		{http.MethodDelete, "/posts/5/", http.StatusMethodNotAllowed, ""},
+		{http.MethodPost, "/posts/", http.StatusMethodNotAllowed, ""},
+	} {
+		w := httptest.NewRecorder()
+		app.Mux().ServeHTTP(w, httptest.NewRequest(item.method, item.path, nil))
+		assert.Equal(t, item.code, w.Code, item.method+" "+item.path)
+		if len(item.body) > 0 {
+			assert.Equal(t, item.body, w.Body.String())
+		}
+	}
+	assert.Len(t, app.Routes(), 3)
+}
+
+func TestRouterHost(t *testing.T) {
+	app := TestApp(t.TempDir())
+	app.Get(
+		"/", func(c Ctx) error {
+			return c.Response().Text("main" + c.Generate().Link("tenant_dashboard"))
+		},
+	)
+	app.Host("api.example.com").Get(
+		"/", func(c Ctx) error {
+			return c.Response().Text("api")
+		}, Name("api"),
+	)
+	app.Host("api.example.com").Get(
+		"/status/", func(c Ctx) error {
+			return c.Response().Text("status")
+		},
+	)
+	app.Host("Docs.Example.com").Get(
+		"/", func(c Ctx) error {
+			return c.Response().Text("docs")
+		},
+	)
+	app.Host("{tenant:slug}.example.com").Get(
+		"/status/", func(c Ctx) error {
+			return c.Response().Text(c.Request().PathValue("tenant") + ":status")
+		},
+	)
+	tenant := app.Host("{tenant:slug}.example.com")
+	tenant.Get(
+		"/", func(c Ctx) error {
+			return c.Response().Text(c.Request().PathValue("tenant") + ":" + c.Generate().Link("tenant_dashboard"))
+		},
+	)
+	tenant.Group("/admin", "tenant").Get(
+		"/dashboard/", func(c Ctx) error {
+			return c.Response().Text(c.Generate().Link("api") + " " + c.Generate().Link("tenant_dashboard", Map{"tenant": "b"}))
+		}, Name("dashboard"),
+	)
+	assert.NoError(t, app.Validate())
+	for _, item := range []struct {
+		method string
+		url    string
+		code   int
+		body   string
+	}{
+		{http.MethodGet, "http://example.com/", http.StatusOK, "main"},
+		{http.MethodGet, "http://api.example.com/", http.StatusOK, "api"},
+		{http.MethodGet, "http://api.example.com/status/", http.StatusOK, "status"},
+		{http.MethodGet, "http://acme.example.com/status/", http.StatusOK, "acme:status"},
+		{http.MethodGet, "http://ACME.Example.com/status/", http.StatusOK, "acme:status"},
+		{http.MethodGet, "http://API.example.com:8080/status/", http.StatusOK, "status"},
+		{http.MethodGet, "http://docs.EXAMPLE.com/", http.StatusOK, "docs"},
+		{http.MethodGet, "http://acme.example.com/", http.StatusOK, "acme:http://acme.example.com/admin/dashboard/"},
+		{
+			http.MethodGet, "http://acme.example.com/admin/dashboard/", http.StatusOK,
+			"http://api.example.com/ http://b.example.com/admin/dashboard/",
+		},
+		{http.MethodGet, "http://acme.example.com/unknown/", http.StatusNotFound, ""},
+		{http.MethodPost, "http://acme.example.com/", http.StatusMethodNotAllowed, ""},
+		{http.MethodGet, "http://example.com/admin/dashboard/", http.StatusNotFound, ""},
+	} {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(item.method, item.url, nil))
+		assert.Equal(t, item.code, w.Code, item.method+" "+item.url)
+		if len(item.body) > 0 {
+			assert.Equal(t, item.body, w.Body.String())
+		}
+	}
+}
+
+func TestRouterMount(t *testing.T) {
+	app := TestApp(t.TempDir())
+	sub := http.NewServeMux()
+	sub.HandleFunc(
+		"GET /hello", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Sub", "true")
+			_, _ = w.Write([]byte("hello " + r.URL.Path))
+		},
+	)
+	sub.HandleFunc(
+		"OPTIONS /hello", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			_, _ = w.Write([]byte("preflight"))
+		},
+	)
+	internal := app.Group("/internal").Use(
+		func(c Ctx) error {
+			if c.Request().Header().Get("secret") != "ok" {
+				return c.Response().Status(http.StatusForbidden).Text("forbidden")
+			}
+			return c.Continue()
+		},
+	)
+	internal.Mount("/app", sub)
+	internal.Handle(
+		"/status/", http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+			},
+		), Method(http.MethodGet),
+	)
+	internal.Handle(
+		"/any/", http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(r.Method))
+			},
+		),
+	)
+	for _, item := range []struct {
+		method string
+		path   string
+		secret string
+		code   int
+		body   string
+	}{
+		{http.MethodGet, "/internal/app/hello", "ok", http.StatusOK, "hello /hello"},
+		{http.MethodGet, "/internal/app/hello", "", http.StatusForbidden, "forbidden"},
+		{http.MethodGet, "/internal/app/unknown", "ok", http.StatusNotFound, ""},
+		{http.MethodOptions, "/internal/app/hello", "ok", http.StatusOK, "preflight"},
+		{http.MethodGet, "/internal/status/", "ok", http.StatusAccepted, ""},
+		{http.MethodGet, "/internal/status/", "", http.StatusForbidden, "forbidden"},
+		{http.MethodOptions, "/internal/status/", "ok", http.StatusNoContent, ""},
+		{http.MethodOptions, "/internal/any/", "ok", http.StatusOK, http.MethodOptions},
+	} {
+		r := httptest.NewRequest(item.method, item.path, nil)
+		r.Header.Set("secret", item.secret)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, r)
+		assert.Equal(t, item.code, w.Code, item.path)
+		if len(item.body) > 0 {
+			assert.Equal(t, item.body, w.Body.String())
+		}
+	}
+}
+
+func TestRouterRedirect(t *testing.T) {
+	app := TestApp(t.TempDir())
+	app.Get("/posts/{id:int}/", testOk, Name("post"))
+	app.Redirect("/articles/{id}/", "post")
+	app.Redirect("/old/{id}/", "/posts/{id}/", http.StatusFound)
+	app.Redirect("/missing/", "unknown")
+	app.Redirects(map[string]string{"/c/": "/d/", "/a/": "/b/", "/e/": "/f/"})
+	paths := make([]string, 0)
+	for _, r := range app.Routes() {
+		paths = append(paths, r.Path)
+	}
+	assert.Subset(t, paths, []string{"/a/", "/c/", "/e/"})
+	assert.Less(t, slices.Index(paths, "/a/"), slices.Index(paths, "/c/"))
+	assert.Less(t, slices.Index(paths, "/c/"), slices.Index(paths, "/e/"))
+	for _, item := range []struct {
+		path     string
+		code     int
+		location string
+	}{
+		{"/articles/5/", http.StatusMovedPermanently, "/posts/5/"},
+		{"/old/7/?page=2", http.StatusFound, "/posts/7/?page=2"},
+		{"/a/", http.StatusMovedPermanently, "/b/"},
+		{"/c/", http.StatusMovedPermanently, "/d/"},
+		{"/missing/", http.StatusNotFound, ""},
+	} {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, item.path, nil))
+		assert.Equal(t, item.code, w.Code, item.path)
+		assert.Equal(t, item.location, w.Header().Get("Location"), item.path)
+	}
+	
+	localized := TestApp(
+		t.TempDir(), config.Config{
+			Localization: config.Localization{
+				Enabled:   true,
+				Path:      true,
+				Languages: []config.Language{{Code: "cs", Main: true}, {Code: "en"}},
+			},
+		},
+	)
+	localized.Get(map[string]string{"cs": "/kontakt/", "en": "/contact/"}, testOk, Name("contact"))
+	localized.Redirect(map[string]string{"cs": "/napiste-nam/", "en": "/write-us/"}, "contact")
+	for path, location := range map[string]string{"/cs/napiste-nam/": "/cs/kontakt/", "/en/write-us/": "/en/contact/"} {
+		w := httptest.NewRecorder()
+		localized.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusMovedPermanently, w.Code, path)
+		assert.Equal(t, location, w.Header().Get("Location"), path)
+	}
+}
+
+func TestRouterTrailingSlash(t *testing.T) {
+	link := func(c Ctx) error {
+		return c.Response().Text(c.Generate().Link("about"))
+	}
+	for _, item := range []struct {
+		policy   int
+		path     string
+		code     int
+		location string
+		body     string
+	}{
+		{TrailingSlashAppend, "/about/", http.StatusOK, "", "/about/"},
+		{TrailingSlashAppend, "/about", http.StatusMovedPermanently, "/about/", ""},
+		{TrailingSlashTrim, "/about", http.StatusOK, "", "/about"},
+		{TrailingSlashTrim, "/about/?page=1", http.StatusMovedPermanently, "/about?page=1", ""},
+		{TrailingSlashStrict, "/about", http.StatusOK, "", "/about"},
+		{TrailingSlashStrict, "/about/", http.StatusNotFound, "", ""},
+		{TrailingSlashStrict, "/contact/", http.StatusOK, "", "/about"},
+		{TrailingSlashStrict, "/contact", http.StatusNotFound, "", ""},
+	} {
+		app := TestApp(t.TempDir())
+		app.TrailingSlash(item.policy)
+		app.Get("/about", link, Name("about"))
+		app.Get("/contact/", link, Name("contact"))
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, item.path, nil))
+		assert.Equal(t, item.code, w.Code, item.path)
+		assert.Equal(t, item.location, w.Header().Get("Location"), item.path)
+		if len(item.body) > 0 {
+			assert.Equal(t, item.body, w.Body.String(), item.path)
+		}
+	}
+}
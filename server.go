@@ -0,0 +1,210 @@
+package cp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+type ServerConfig struct {
+	ReadTimeout           time.Duration
+	ReadHeaderTimeout     time.Duration
+	WriteTimeout          time.Duration
+	IdleTimeout           time.Duration
+	ShutdownTimeout       time.Duration
+	RedirectAddress       string
+	HstsMaxAge            time.Duration
+	HstsIncludeSubDomains bool
+	HstsPreload           bool
+	TrustedProxies        []string
+	ProxyHeader           string
+}
+
+const (
+	headerStrictTransportSecurity = "Strict-Transport-Security"
+)
+
+var (
+	defaultServerConfig = ServerConfig{
+		ReadTimeout:       30 * time.Second,
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		ShutdownTimeout:   15 * time.Second,
+	}
+)
+
+func (c *core) Server(config ServerConfig) Creampuff {
+	if config.ReadTimeout == 0 {
+		config.ReadTimeout = defaultServerConfig.ReadTimeout
+	}
+	if config.ReadHeaderTimeout == 0 {
+		config.ReadHeaderTimeout = defaultServerConfig.ReadHeaderTimeout
+	}
+	if config.WriteTimeout == 0 {
+		config.WriteTimeout = defaultServerConfig.WriteTimeout
+	}
+	if config.IdleTimeout == 0 {
+		config.IdleTimeout = defaultServerConfig.IdleTimeout
+	}
+	if config.ShutdownTimeout == 0 {
+		config.ShutdownTimeout = defaultServerConfig.ShutdownTimeout
+	}
+	proxies, err := createProxyConfig(config.TrustedProxies, config.ProxyHeader)
+	if err != nil {
+		c.issues = append(c.issues, err)
+	}
+	c.serverConfig = config
+	c.trustedProxies = proxies
+	return c
+}
+
+func (c *core) RunContext(ctx context.Context, address string) error {
+	return c.serve(
+		ctx, []*http.Server{c.createServer(address, c)}, func(server *http.Server) error {
+			return server.ListenAndServe()
+		},
+	)
+}
+
+func (c *core) RunTLS(address, certFile, keyFile string) {
+	if err := c.RunTLSContext(context.Background(), address, certFile, keyFile); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func (c *core) RunTLSContext(ctx context.Context, address, certFile, keyFile string) error {
+	servers := []*http.Server{c.createServer(address, c)}
+	if len(c.serverConfig.RedirectAddress) > 0 {
+		servers = append(servers, c.createServer(c.serverConfig.RedirectAddress, c.createHttpsRedirectHandler(address)))
+	}
+	return c.serve(
+		ctx, servers, func(server *http.Server) error {
+			if server != servers[0] {
+				return server.ListenAndServe()
+			}
+			return server.ListenAndServeTLS(certFile, keyFile)
+		},
+	)
+}
+
+func (c *core) Shutdown(ctx context.Context) error {
+	c.serverMu.Lock()
+	if c.stopped {
+		c.serverMu.Unlock()
+		select {
+		case <-c.done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	c.stopped = true
+	servers := c.servers
+	c.serverMu.Unlock()
+	defer close(c.done)
+	errs := make([]error, 0)
+	for _, server := range servers {
+		errs = append(errs, server.Shutdown(ctx))
+	}
+	errs = append(errs, c.runShutdownHooks(ctx))
+	return errors.Join(errs...)
+}
+
+func (c *core) serve(ctx context.Context, servers []*http.Server, listen func(server *http.Server) error) error {
+	c.serverMu.Lock()
+	stopped := c.stopped
+	c.serverMu.Unlock()
+	if stopped {
+		<-c.done
+		return nil
+	}
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	if err := c.resolveServices(ctx); err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := c.runStartHooks(ctx); err != nil {
+		return err
+	}
+	c.serverMu.Lock()
+	if c.stopped {
+		c.serverMu.Unlock()
+		<-c.done
+		return nil
+	}
+	c.servers = servers
+	c.serverMu.Unlock()
+	fmt.Println(logo)
+	serveErr := make(chan error, len(servers))
+	for _, server := range servers {
+		go func(server *http.Server) {
+			serveErr <- listen(server)
+		}(server)
+	}
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			<-c.done
+			return nil
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), c.serverConfig.ShutdownTimeout)
+		defer cancel()
+		return errors.Join(err, c.Shutdown(shutdownCtx))
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), c.serverConfig.ShutdownTimeout)
+	defer cancel()
+	return c.Shutdown(shutdownCtx)
+}
+
+func (c *core) createHttpsRedirectHandler(address string) http.Handler {
+	_, port, _ := net.SplitHostPort(address)
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			host := parseHostname(r.Host)
+			if len(port) > 0 && port != "443" {
+				host = net.JoinHostPort(host, port)
+			}
+			http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+		},
+	)
+}
+
+func (c *core) setHstsHeader(w http.ResponseWriter, r *http.Request) {
+	if c.serverConfig.HstsMaxAge <= 0 {
+		return
+	}
+	if (request{r: r, proxies: c.trustedProxies}).Protocol() != "https" {
+		return
+	}
+	value := fmt.Sprintf("max-age=%d", int(c.serverConfig.HstsMaxAge.Seconds()))
+	if c.serverConfig.HstsIncludeSubDomains {
+		value += "; includeSubDomains"
+	}
+	if c.serverConfig.HstsPreload {
+		value += "; preload"
+	}
+	w.Header().Set(headerStrictTransportSecurity, value)
+}
+
+func (c *core) createServer(address string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              address,
+		Handler:           handler,
+		ReadTimeout:       c.serverConfig.ReadTimeout,
+		ReadHeaderTimeout: c.serverConfig.ReadHeaderTimeout,
+		WriteTimeout:      c.serverConfig.WriteTimeout,
+		IdleTimeout:       c.serverConfig.IdleTimeout,
+	}
+}
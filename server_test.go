@@ -0,0 +1,144 @@
+package cp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+	
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerShutdown(t *testing.T) {
+	app := TestApp(t.TempDir())
+	calls := make([]string, 0)
+	app.OnStart(
+		func(ctx context.Context) error {
+			calls = append(calls, "start")
+			return nil
+		},
+	)
+	app.OnShutdown(
+		func(ctx context.Context) error {
+			calls = append(calls, "db")
+			return nil
+		},
+		func(ctx context.Context) error {
+			calls = append(calls, "cache")
+			return nil
+		},
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- app.RunContext(ctx, "127.0.0.1:0")
+	}()
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Equal(t, []string{"start", "cache", "db"}, calls)
+}
+
+func TestServerHttps(t *testing.T) {
+	app := TestApp(t.TempDir())
+	app.Server(ServerConfig{HstsMaxAge: 24 * time.Hour, HstsIncludeSubDomains: true, TrustedProxies: []string{"10.0.0.1"}})
+	app.Get("/", testOk)
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("X-Forwarded-Proto", "https")
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, r)
+	assert.Equal(t, "max-age=86400; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+	
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Empty(t, w.Header().Get("Strict-Transport-Security"))
+	
+	w = httptest.NewRecorder()
+	app.(*core).createHttpsRedirectHandler(":8443").ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/a/?b=1", nil))
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "https://example.com:8443/a/?b=1", w.Header().Get("Location"))
+}
+
+func TestServerShutdownConcurrent(t *testing.T) {
+	t.Run(
+		"before run", func(t *testing.T) {
+			app := TestApp(t.TempDir())
+			var starts, calls int
+			app.OnStart(
+				func(ctx context.Context) error {
+					starts++
+					return nil
+				},
+			)
+			app.OnShutdown(
+				func(ctx context.Context) error {
+					calls++
+					return nil
+				},
+			)
+			assert.NoError(t, app.Shutdown(context.Background()))
+			assert.NoError(t, app.Shutdown(context.Background()))
+			assert.NoError(t, app.RunContext(context.Background(), "127.0.0.1:0"))
+			assert.Equal(t, 0, starts)
+			assert.Equal(t, 1, calls)
+		},
+	)
+	t.Run(
+		"while running", func(t *testing.T) {
+			app := TestApp(t.TempDir())
+			started := make(chan struct{})
+			var calls int
+			app.OnStart(
+				func(ctx context.Context) error {
+					close(started)
+					return nil
+				},
+			)
+			app.OnShutdown(
+				func(ctx context.Context) error {
+					calls++
+					return nil
+				},
+			)
+			done := make(chan error, 1)
+			go func() {
+				done <- app.RunContext(context.Background(), "127.0.0.1:0")
+			}()
+			<-started
+			assert.NoError(t, app.Shutdown(context.Background()))
+			assert.NoError(t, <-done)
+			assert.NoError(t, app.Shutdown(context.Background()))
+			assert.Equal(t, 1, calls)
+		},
+	)
+	t.Run(
+		"from another goroutine", func(t *testing.T) {
+			app := TestApp(t.TempDir())
+			var finished atomic.Bool
+			app.OnShutdown(
+				func(ctx context.Context) error {
+					time.Sleep(50 * time.Millisecond)
+					finished.Store(true)
+					return nil
+				},
+			)
+			go func() {
+				for {
+					c := app.(*core)
+					c.serverMu.Lock()
+					running := len(c.servers) > 0
+					c.serverMu.Unlock()
+					if running {
+						break
+					}
+					time.Sleep(time.Millisecond)
+				}
+				assert.NoError(t, app.Shutdown(context.Background()))
+			}()
+			assert.NoError(t, app.RunContext(context.Background(), "127.0.0.1:0"))
+			assert.True(t, finished.Load())
+		},
+	)
+}
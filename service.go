@@ -0,0 +1,111 @@
+package cp
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type Provider func(ctx context.Context) (any, error)
+
+type RequestProvider func(c Ctx) (any, error)
+
+type service struct {
+	mu              sync.Mutex
+	provider        Provider
+	requestProvider RequestProvider
+	resolved        bool
+	value           any
+}
+
+type serviceResolving struct{}
+
+func Service[T any](c Ctx, name ...string) T {
+	var key any = reflect.TypeFor[T]()
+	if len(name) > 0 {
+		key = name[0]
+	}
+	v, ok := c.Service(key).(T)
+	if !ok {
+		panic(fmt.Errorf("%w: %v", ErrorInvalidService, key))
+	}
+	return v
+}
+
+func (c *core) Provide(key any, provider Provider) Creampuff {
+	c.services[createServiceKey(key)] = &service{provider: provider}
+	return c
+}
+
+func (c *core) ProvideRequest(key any, provider RequestProvider) Creampuff {
+	c.services[createServiceKey(key)] = &service{requestProvider: provider}
+	return c
+}
+
+func (c *core) resolveServices(ctx context.Context) error {
+	for key, s := range c.services {
+		if s.provider == nil {
+			continue
+		}
+		if _, err := s.resolve(ctx); err != nil {
+			return fmt.Errorf("%w: %v: %w", ErrorInvalidService, key, err)
+		}
+	}
+	return nil
+}
+
+func (c *ctx) Service(key any) any {
+	key = createServiceKey(key)
+	s, ok := c.services[key]
+	if !ok {
+		panic(fmt.Errorf("%w: %v", ErrorInvalidService, key))
+	}
+	if s.requestProvider == nil {
+		v, err := s.resolve(context.Background())
+		if err != nil {
+			panic(err)
+		}
+		return v
+	}
+	if v, ok := c.instances[key]; ok {
+		if _, resolving := v.(serviceResolving); resolving {
+			panic(fmt.Errorf("%w: %v", ErrorCircularService, key))
+		}
+		return v
+	}
+	c.instances[key] = serviceResolving{}
+	v, err := s.requestProvider(c)
+	if err != nil {
+		delete(c.instances, key)
+		panic(err)
+	}
+	c.instances[key] = v
+	return v
+}
+
+func (s *service) resolve(ctx context.Context) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.resolved {
+		return s.value, nil
+	}
+	v, err := s.provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.value = v
+	s.resolved = true
+	return s.value, nil
+}
+
+func createServiceKey(key any) any {
+	switch k := key.(type) {
+	case string:
+		return k
+	case reflect.Type:
+		return k
+	default:
+		return reflect.TypeOf(key)
+	}
+}
@@ -0,0 +1,128 @@
+package cp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	
+	"github.com/stretchr/testify/assert"
+)
+
+type testCounterService struct {
+	id int
+}
+
+func TestService(t *testing.T) {
+	var singletons, requests int
+	app := TestApp(t.TempDir())
+	app.Provide(
+		&testCounterService{}, func(ctx context.Context) (any, error) {
+			singletons++
+			return &testCounterService{id: singletons}, nil
+		},
+	)
+	app.ProvideRequest(
+		"request", func(c Ctx) (any, error) {
+			requests++
+			singleton := Service[*testCounterService](c)
+			return &testCounterService{id: singleton.id*10 + requests}, nil
+		},
+	)
+	app.ProvideRequest(
+		"circular", func(c Ctx) (any, error) {
+			return Service[*testCounterService](c, "circular"), nil
+		},
+	)
+	app.Use(
+		func(c Ctx) error {
+			Service[*testCounterService](c, "request")
+			return c.Continue()
+		},
+	)
+	app.Get(
+		"/", func(c Ctx) error {
+			singleton := Service[*testCounterService](c)
+			request := Service[*testCounterService](c, "request")
+			assert.Panics(
+				t, func() {
+					Service[*testCounterService](c, "missing")
+				},
+			)
+			assert.PanicsWithError(
+				t, ErrorCircularService.Error()+": circular", func() {
+					Service[*testCounterService](c, "circular")
+				},
+			)
+			return c.Response().Text(fmt.Sprintf("%d:%d", singleton.id, request.id))
+		},
+	)
+	for _, expected := range []string{"1:11", "1:12"} {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, expected, w.Body.String())
+	}
+}
+
+type testServiceContextKey struct{}
+
+func TestServiceStartup(t *testing.T) {
+	app := TestApp(t.TempDir())
+	app.Provide(
+		"app", func(ctx context.Context) (any, error) {
+			return ctx.Value(testServiceContextKey{}), nil
+		},
+	)
+	ctx := context.WithValue(context.Background(), testServiceContextKey{}, "run")
+	assert.NoError(t, app.(*core).resolveServices(ctx))
+	app.Get(
+		"/", func(c Ctx) error {
+			return c.Response().Text(Service[string](c, "app"))
+		},
+	)
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "run", w.Body.String())
+	
+	app = TestApp(t.TempDir())
+	app.Provide(
+		"broken", func(ctx context.Context) (any, error) {
+			return nil, errors.New("broken")
+		},
+	)
+	assert.ErrorIs(t, app.(*core).resolveServices(context.Background()), ErrorInvalidService)
+}
+
+func TestServiceConcurrent(t *testing.T) {
+	var calls atomic.Int32
+	app := TestApp(t.TempDir())
+	app.Provide(
+		&testCounterService{}, func(ctx context.Context) (any, error) {
+			time.Sleep(10 * time.Millisecond)
+			return &testCounterService{id: int(calls.Add(1))}, nil
+		},
+	)
+	app.Get(
+		"/", func(c Ctx) error {
+			return c.Response().Text(fmt.Sprint(Service[*testCounterService](c).id))
+		},
+	)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.Equal(t, "1", w.Body.String())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,76 @@
+package cp
+
+import (
+	"net/http"
+	"strings"
+)
+
+type patternHandler struct {
+	fn          http.HandlerFunc
+	counterpart bool
+}
+
+type patternKey struct {
+	mux     *http.ServeMux
+	pattern string
+}
+
+const (
+	TrailingSlashAppend = iota
+	TrailingSlashTrim
+	TrailingSlashStrict
+)
+
+func (r *router) TrailingSlash(policy int) Router {
+	r.trailingSlash = policy
+	return r
+}
+
+func (r *router) canonicalizePath(path string) string {
+	if path == "/" || strings.Contains(path, "...") {
+		return path
+	}
+	switch r.trailingSlash {
+	case TrailingSlashAppend:
+		if !strings.HasSuffix(path, "/") {
+			return path + "/"
+		}
+	case TrailingSlashTrim:
+		return strings.TrimSuffix(path, "/")
+	}
+	return path
+}
+
+func (r *router) createCounterpartPath(path string) (string, bool) {
+	if path == "/" || strings.Contains(path, "...") {
+		return "", false
+	}
+	return toggleTrailingSlash(path), true
+}
+
+func (r *router) createCounterpartHandler() http.HandlerFunc {
+	if r.trailingSlash == TrailingSlashStrict {
+		return r.fallback
+	}
+	return func(w http.ResponseWriter, req *http.Request) {
+		u := *req.URL
+		u.Path = toggleTrailingSlash(u.Path)
+		u.RawPath = ""
+		statusCode := http.StatusPermanentRedirect
+		if req.Method == http.MethodGet || req.Method == http.MethodHead {
+			statusCode = http.StatusMovedPermanently
+		}
+		http.Redirect(w, req, u.RequestURI(), statusCode)
+	}
+}
+
+func (h *patternHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.fn(w, r)
+}
+
+func toggleTrailingSlash(path string) string {
+	if strings.HasSuffix(path, "/") {
+		return strings.TrimSuffix(path, "/")
+	}
+	return path + "/"
+}
@@ -39,6 +39,17 @@ func TestCtx(param TestCtxParam) Ctx {
 	)
 }
 
+func TestApp(tempDir string, cfg ...config.Config) Creampuff {
+	c := config.Config{}
+	if len(cfg) > 0 {
+		c = cfg[0]
+	}
+	if c.Cache.Memory == nil && c.Cache.Redis == nil {
+		c.Cache.Memory = memory.New(tempDir)
+	}
+	return New(c)
+}
+
 func TestRoute(param TestRouteParam) *httptest.ResponseRecorder {
 	cfg := config.Config{
 		App: config.App{
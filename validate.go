@@ -0,0 +1,67 @@
+package cp
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+func (c *core) Validate() error {
+	issues := slices.Clone(c.issues)
+	issues = append(issues, c.validateRouteNames()...)
+	issues = append(issues, c.validateLayouts()...)
+	return errors.Join(issues...)
+}
+
+func (c *core) validateConfig() []error {
+	issues := make([]error, 0)
+	cfg := c.config.Localization
+	if cfg.Enabled && len(cfg.Languages) == 0 {
+		issues = append(issues, ErrorMissingLanguages)
+	}
+	if prefix, ok := c.config.Router.Prefix.Path.(map[string]string); ok {
+		for l := range prefix {
+			if !c.router.languageExists(l) {
+				issues = append(issues, fmt.Errorf("%w: router prefix uses %s", ErrorInvalidLanguage, l))
+			}
+		}
+	}
+	return issues
+}
+
+func (c *core) validateRouteNames() []error {
+	issues := make([]error, 0)
+	names := make(map[string]*Route)
+	for _, r := range *c.router.routes {
+		if len(r.Name) == 0 {
+			continue
+		}
+		key := r.Lang + namePrefixDivider + r.Name
+		existing, ok := names[key]
+		if ok && existing.Path == r.Path && existing.Host == r.Host {
+			continue
+		}
+		if ok {
+			issues = append(
+				issues,
+				fmt.Errorf("%w: %s is used by %s and %s", ErrorDuplicateRouteName, r.Name, existing.Path, r.Path),
+			)
+			continue
+		}
+		names[key] = r
+	}
+	return issues
+}
+
+func (c *core) validateLayouts() []error {
+	issues := make([]error, 0)
+	for _, r := range *c.router.routes {
+		if len(r.Layout) == 0 {
+			continue
+		}
+		if _, ok := c.layout.factories[r.Layout]; !ok {
+			issues = append(issues, fmt.Errorf("%w: route %s uses %s", ErrorInvalidLayout, r.Path, r.Layout))
+		}
+	}
+	return issues
+}
@@ -0,0 +1,10 @@
+package cp
+
+func Value[T any](c Ctx, key string) T {
+	v, ok := c.Get(key).(T)
+	if !ok {
+		var zero T
+		return zero
+	}
+	return v
+}
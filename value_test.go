@@ -0,0 +1,50 @@
+package cp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	
+	"github.com/stretchr/testify/assert"
+	
+	"github.com/creamsensation/gox"
+)
+
+type testUserComponent struct {
+	Component
+	user string
+}
+
+func (c *testUserComponent) Name() string {
+	return "user"
+}
+
+func (c *testUserComponent) Mount() {
+	c.user = Value[string](c, "user")
+}
+
+func (c *testUserComponent) Node() gox.Node {
+	return gox.Text(c.user)
+}
+
+func TestValue(t *testing.T) {
+	app := TestApp(t.TempDir())
+	app.Use(
+		func(c Ctx) error {
+			c.Set("user", "admin")
+			return c.Continue()
+		},
+	)
+	app.Get(
+		"/", func(c Ctx) error {
+			assert.Equal(t, "admin", c.Get("user"))
+			assert.Equal(t, 0, Value[int](c, "user"))
+			assert.Nil(t, c.Get("missing"))
+			return c.Response().Text(gox.Render(c.Create().Component(&testUserComponent{})))
+		}, Name("index"),
+	)
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "admin", w.Body.String())
+}